@@ -0,0 +1,212 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Bitcoin legacy addresses (P2PKH and P2SH), built on top of Base58Check.
+//
+
+// Package address implements Bitcoin legacy addresses (P2PKH and P2SH) for mainnet, testnet and regtest.
+package address
+
+//
+// Imports
+//
+import (
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Bitcoin mainnet
+	Mainnet Network = 0
+	// Bitcoin testnet
+	Testnet Network = 1
+	// Bitcoin regtest (same version bytes of testnet)
+	Regtest Network = 2
+
+	// Pay to public key hash
+	P2PKH Type = 0
+	// Pay to script hash
+	P2SH Type = 1
+
+	// Hash length (hash160)
+	HashLen = 20
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidNetwork is returned when using a not-existent network
+	ErrInvalidNetwork = errors.New("The specified network is not existent")
+	// ErrInvalidType is returned when using a not-existent address type
+	ErrInvalidType = errors.New("The specified address type is not existent")
+	// ErrInvalidLength is returned when the address hash length is not valid
+	ErrInvalidLength = errors.New("The address hash length is not valid")
+	// ErrInvalidVersion is returned when the address version byte is not valid
+	ErrInvalidVersion = errors.New("The address version byte is not valid")
+
+	// Map from network to version bytes, indexed by address type
+	versionMap = map[Network][2]byte {
+		Mainnet: [2]byte { 0x00, 0x05 },
+		Testnet: [2]byte { 0x6f, 0xc4 },
+		Regtest: [2]byte { 0x6f, 0xc4 },
+	}
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Network type
+type Network int
+
+// Address type
+type Type int
+
+// Address structure.
+type Address struct {
+	Net  Network
+	Type Type
+	Hash [HashLen]byte
+}
+
+//
+// Exported functions
+//
+
+// Create a new address from the specified network, type and hash.
+func New(net Network, addrType Type, hash []byte) (*Address, error) {
+	if _, ok := versionMap[net]; !ok {
+		return nil, ErrInvalidNetwork
+	}
+	if addrType != P2PKH && addrType != P2SH {
+		return nil, ErrInvalidType
+	}
+	if len(hash) != HashLen {
+		return nil, ErrInvalidLength
+	}
+
+	addr := &Address {
+		Net:  net,
+		Type: addrType,
+	}
+	copy(addr.Hash[:], hash)
+
+	return addr, nil
+}
+
+// Decode the specified address string.
+// Since testnet and regtest share the same version bytes, their addresses are decoded as Testnet.
+// Use DecodeForNetwork for decoding regtest addresses.
+func Decode(addrStr string) (*Address, error) {
+	version, hash, err := base58Btc.CheckDecodeVersion(addrStr, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, net := range []Network { Mainnet, Testnet } {
+		if addr, err := newFromVersion(net, version[0], hash); err != ErrInvalidVersion {
+			return addr, err
+		}
+	}
+
+	return nil, ErrInvalidVersion
+}
+
+// Decode the specified address string, checking that it belongs to the specified network.
+func DecodeForNetwork(addrStr string, net Network) (*Address, error) {
+	if _, ok := versionMap[net]; !ok {
+		return nil, ErrInvalidNetwork
+	}
+
+	version, hash, err := base58Btc.CheckDecodeVersion(addrStr, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFromVersion(net, version[0], hash)
+}
+
+// Validate the address structure.
+func (addr *Address) Validate() error {
+	if _, ok := versionMap[addr.Net]; !ok {
+		return ErrInvalidNetwork
+	}
+	if addr.Type != P2PKH && addr.Type != P2SH {
+		return ErrInvalidType
+	}
+	return nil
+}
+
+// Get the version byte of the address.
+func (addr *Address) Version() (byte, error) {
+	if err := addr.Validate(); err != nil {
+		return 0, err
+	}
+	return versionMap[addr.Net][addr.Type], nil
+}
+
+// Get if the address is valid for the specified network.
+// Testnet addresses are also valid for regtest and vice versa.
+func (addr *Address) IsForNet(net Network) bool {
+	versions, ok := versionMap[net]
+	return ok && versions == versionMap[addr.Net]
+}
+
+// Encode the address.
+// It returns an error if the network or type is not valid.
+func (addr *Address) Encode() (string, error) {
+	version, err := addr.Version()
+	if err != nil {
+		return "", err
+	}
+	return base58Btc.CheckEncodeVersion([]byte { version }, addr.Hash[:]), nil
+}
+
+// Get the address string.
+// It returns an empty string if the network or type is not valid, use Encode for getting the error.
+func (addr *Address) String() string {
+	enc, _ := addr.Encode()
+	return enc
+}
+
+//
+// Not-exported functions
+//
+
+// Create a new address from the specified network, version byte and hash
+func newFromVersion(net Network, version byte, hash []byte) (*Address, error) {
+	versions := versionMap[net]
+
+	for addrType, currVersion := range versions {
+		if currVersion == version {
+			return New(net, Type(addrType), hash)
+		}
+	}
+
+	return nil, ErrInvalidVersion
+}
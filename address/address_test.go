@@ -0,0 +1,229 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package address
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Addr string
+	Hash string
+	Net  Network
+	Type Type
+}
+
+//
+// Variables
+//
+
+// Valid addresses (from Bitcoin Core base58_keys_valid.json)
+var testVectValid = []testVectEntry {
+	testVectEntry {
+		Addr: "1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i",
+		Hash: "65a16059864a2fdbc7c99a4723a8395bc6f188eb",
+		Net:  Mainnet,
+		Type: P2PKH,
+	},
+	testVectEntry {
+		Addr: "3CMNFxN1oHBc4R1EpboAL5yzHGgE611Xou",
+		Hash: "74f209f6ea907e2ea48f74fae05782ae8a665257",
+		Net:  Mainnet,
+		Type: P2SH,
+	},
+	testVectEntry {
+		Addr: "mo9ncXisMeAoXwqcV5EWuyncbmCcQN4rVs",
+		Hash: "53c0307d6851aa0ce7825ba883c6bd9ad242b486",
+		Net:  Testnet,
+		Type: P2PKH,
+	},
+	testVectEntry {
+		Addr: "2N2JD6wb56AfK4tfmM6PwdVmoYk2dCKf4Br",
+		Hash: "6349a418fc4578d10a372b54b45c280cc8c4382f",
+		Net:  Testnet,
+		Type: P2SH,
+	},
+	testVectEntry {
+		Addr: "1Ax4gZtb7gAit2TivwejZHYtNNLT18PUXJ",
+		Hash: "6d23156cbbdcc82a5a47eee4c2c7c583c18b6bf4",
+		Net:  Mainnet,
+		Type: P2PKH,
+	},
+	testVectEntry {
+		Addr: "3QjYXhTkvuj8qPaXHTTWb5wjXhdsLAAWVy",
+		Hash: "fcc5460dd6e2487c7d75b1963625da0e8f4c5975",
+		Net:  Mainnet,
+		Type: P2SH,
+	},
+	testVectEntry {
+		Addr: "n3ZddxzLvAY9o7184TB4c6FJasAybsw4HZ",
+		Hash: "f1d470f9b02370fdec2e6b708b08ac431bf7a5f7",
+		Net:  Testnet,
+		Type: P2PKH,
+	},
+	testVectEntry {
+		Addr: "2NBFNJTktNa7GZusGbDbGKRZTxdK9VVez3n",
+		Hash: "c579342c2c4c9220205e2cdc285617040c924a0a",
+		Net:  Testnet,
+		Type: P2SH,
+	},
+}
+
+// Invalid addresses
+var testVectInvalid = []string {
+	// Empty and too short
+	"",
+	"x",
+	"1",
+	// Invalid checksum
+	"1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62j",
+	"3CMNFxN1oHBc4R1EpboAL5yzHGgE611Xov",
+	// Invalid characters
+	"1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW60i",
+	"0AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i",
+	// Invalid (from Bitcoin Core base58_keys_invalid.json)
+	"37qgekLpCCHrQuSjvX3fs496FWTGsHFHizjJAs6NPcR47aefnnCWECAhHV6E3g4YN7u7Yuwod5Y",
+	"dzb7VV1Ui55BARxv7ATxAtCUeJsANKovDGWFVgpTbhq9gvPqP3yv",
+	"MuNu7ZAEDFiHthiunm7dPjwKqrVNCM3mAz6rP9zFveQu14YA8CxExSJTHcVP9DErn6u84E6Ej7S",
+	"rPpQpYknyNQ5AEHuY6H8ijJJrYc2nDKKk9jjmKEXsWzyAQcFGpDLU2Zvsmoi8JLR7hAwoy3RQWf",
+}
+
+//
+// Functions
+//
+
+// Test valid addresses
+func TestValid(t *testing.T) {
+	for _, currTest := range testVectValid {
+		hash, _ := hex.DecodeString(currTest.Hash)
+
+		// Decode
+		addr, err := Decode(currTest.Addr)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if addr.Net != currTest.Net || addr.Type != currTest.Type || hex.EncodeToString(addr.Hash[:]) != currTest.Hash {
+			t.Errorf("Decoding (%s) was incorrect: got %v", currTest.Addr, addr)
+		}
+
+		// Encode
+		addr, err = New(currTest.Net, currTest.Type, hash)
+		if err != nil {
+			t.Errorf("Creating address (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if addr.String() != currTest.Addr {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Addr, addr.String())
+		}
+
+		// Testnet addresses are valid for regtest
+		addr, err = DecodeForNetwork(currTest.Addr, Regtest)
+		if (err == nil) != (currTest.Net == Testnet) {
+			t.Errorf("Decoding (%s) for regtest returned wrong result", currTest.Addr)
+		}
+		if err == nil && (addr.Net != Regtest || !addr.IsForNet(Testnet) || addr.IsForNet(Mainnet)) {
+			t.Errorf("Decoding (%s) for regtest was incorrect", currTest.Addr)
+		}
+
+		// Decoding for the wrong network shall fail
+		wrongNet := Mainnet
+		if currTest.Net == Mainnet {
+			wrongNet = Testnet
+		}
+		if _, err = DecodeForNetwork(currTest.Addr, wrongNet); err != ErrInvalidVersion {
+			t.Errorf("Decoding (%s) for wrong network returned wrong error", currTest.Addr)
+		}
+	}
+}
+
+// Test invalid addresses
+func TestInvalid(t *testing.T) {
+	for _, currTest := range testVectInvalid {
+		addr, err := Decode(currTest)
+		if addr != nil || err == nil {
+			t.Errorf("Decoding invalid address (%s) returned no error", currTest)
+		}
+	}
+
+	base58Btc := base58.New(base58.AlphabetBitcoin)
+	hash, _ := hex.DecodeString("65a16059864a2fdbc7c99a4723a8395bc6f188eb")
+
+	// Invalid version
+	if _, err := Decode(base58Btc.CheckEncodeVersion([]byte { 0x01 }, hash)); err != ErrInvalidVersion {
+		t.Errorf("Decoding address with invalid version returned wrong error")
+	}
+	// Invalid length
+	if _, err := Decode(base58Btc.CheckEncodeVersion([]byte { 0x00 }, hash[1:])); err != ErrInvalidLength {
+		t.Errorf("Decoding address with short hash returned wrong error")
+	}
+	if _, err := Decode(base58Btc.CheckEncodeVersion([]byte { 0x05 }, append(hash, 0x00))); err != ErrInvalidLength {
+		t.Errorf("Decoding address with long hash returned wrong error")
+	}
+	if _, err := Decode(base58Btc.CheckEncode(nil)); err != base58.ErrInputTooShort {
+		t.Errorf("Decoding address without version returned wrong error")
+	}
+
+	// Invalid parameters
+	if _, err := New(Network(3), P2PKH, hash); err != ErrInvalidNetwork {
+		t.Errorf("Creating address with invalid network returned wrong error")
+	}
+	if _, err := New(Mainnet, Type(2), hash); err != ErrInvalidType {
+		t.Errorf("Creating address with invalid type returned wrong error")
+	}
+	if _, err := New(Mainnet, P2PKH, hash[1:]); err != ErrInvalidLength {
+		t.Errorf("Creating address with invalid hash returned wrong error")
+	}
+	if _, err := DecodeForNetwork("1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i", Network(3)); err != ErrInvalidNetwork {
+		t.Errorf("Decoding address with invalid network returned wrong error")
+	}
+
+	// Invalid fields
+	invalidAddrs := []struct {
+		Addr Address
+		Err  error
+	} {
+		{ Address { Net: Network(9) }, ErrInvalidNetwork },
+		{ Address { Type: Type(5) }, ErrInvalidType },
+	}
+	for _, currTest := range invalidAddrs {
+		if _, err := currTest.Addr.Version(); err != currTest.Err {
+			t.Errorf("Getting version of invalid address returned wrong error")
+		}
+		if enc, err := currTest.Addr.Encode(); enc != "" || err != currTest.Err {
+			t.Errorf("Encoding invalid address returned wrong result")
+		}
+		if currTest.Addr.String() != "" {
+			t.Errorf("Getting string of invalid address was not empty")
+		}
+	}
+}
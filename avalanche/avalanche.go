@@ -0,0 +1,136 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Avalanche CB58 encoding and IDs, built on top of Base58 with SHA256 checksum.
+//
+
+// Package avalanche implements the Avalanche CB58 encoding, with 32-byte IDs and 20-byte node IDs.
+package avalanche
+
+//
+// Imports
+//
+import (
+	"errors"
+	"strings"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// ID length (transactions, assets, blockchains, ...)
+	IDLen = 32
+	// Node ID length
+	NodeIDLen = 20
+
+	// Node ID prefix
+	NodeIDPrefix = "NodeID-"
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidLength is returned when the decoded length is not valid
+	ErrInvalidLength = errors.New("The decoded length is not valid")
+	// ErrInvalidNodeIDPrefix is returned when the node ID does not begin with the "NodeID-" prefix
+	ErrInvalidNodeIDPrefix = errors.New("The node ID prefix is not valid")
+
+	// Base58 object with CB58 checksum (last 4 bytes of SHA256)
+	base58Cb58 = base58.New(base58.AlphabetBitcoin).WithChecksum(base58.ChecksumSha256Tail)
+)
+
+//
+// Types
+//
+
+// ID
+type ID [IDLen]byte
+
+// Node ID
+type NodeID [NodeIDLen]byte
+
+//
+// Exported functions
+//
+
+// Encode the specified bytes to CB58.
+func EncodeCB58(data []byte) string {
+	return base58Cb58.CheckEncode(data)
+}
+
+// Decode the specified CB58 string to bytes.
+func DecodeCB58(cb58Str string) ([]byte, error) {
+	return base58Cb58.CheckDecode(cb58Str)
+}
+
+// Decode the specified CB58 ID string.
+func DecodeID(idStr string) (ID, error) {
+	var id ID
+	if err := decodeExact(id[:], idStr); err != nil {
+		return ID {}, err
+	}
+	return id, nil
+}
+
+// Get the CB58 ID string.
+func (id ID) String() string {
+	return EncodeCB58(id[:])
+}
+
+// Decode the specified node ID string ("NodeID-...").
+func DecodeNodeID(nodeIDStr string) (NodeID, error) {
+	if !strings.HasPrefix(nodeIDStr, NodeIDPrefix) {
+		return NodeID {}, ErrInvalidNodeIDPrefix
+	}
+
+	var nodeID NodeID
+	if err := decodeExact(nodeID[:], nodeIDStr[len(NodeIDPrefix):]); err != nil {
+		return NodeID {}, err
+	}
+	return nodeID, nil
+}
+
+// Get the node ID string ("NodeID-...").
+func (nodeID NodeID) String() string {
+	return NodeIDPrefix + EncodeCB58(nodeID[:])
+}
+
+//
+// Not-exported functions
+//
+
+// Decode the specified CB58 string into the specified slice, whose length shall be matched exactly
+func decodeExact(dst []byte, input string) error {
+	dec, err := DecodeCB58(input)
+	if err != nil {
+		return err
+	}
+	if len(dec) != len(dst) {
+		return ErrInvalidLength
+	}
+
+	copy(dst, dec)
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package avalanche
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Str string
+	Hex string
+}
+
+//
+// Variables
+//
+
+// CB58 strings (from Avalanche documentation)
+var testVectCB58 = []testVectEntry {
+	testVectEntry {
+		Str: "1NVSVezva3bAtJesnUj",
+		Hex: "00010203040506070809ff",
+	},
+}
+
+// IDs (mainnet)
+var testVectID = []testVectEntry {
+	// Empty ID (P-chain)
+	testVectEntry {
+		Str: "11111111111111111111111111111111LpoYY",
+		Hex: "0000000000000000000000000000000000000000000000000000000000000000",
+	},
+	// AVAX asset
+	testVectEntry {
+		Str: "FvwEAhmxKfeiG8SnEvq42hc6whRyY3EFYAvebMqDNDGCgxN5Z",
+		Hex: "21e67317cbc4be2aeb00677ad6462778a8f52274b9d605df2591b23027a87dff",
+	},
+	// X-chain
+	testVectEntry {
+		Str: "2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM",
+		Hex: "ed5f38341e436e5d46e2bb00b45d62ae97d1b050c64bc634ae10626739e35c4b",
+	},
+	// C-chain
+	testVectEntry {
+		Str: "2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5",
+		Hex: "0427d4b22a2a78bcddd456742caf91b56badbff985ee19aef14573e7343fd652",
+	},
+}
+
+// Node IDs
+var testVectNodeID = []testVectEntry {
+	testVectEntry {
+		Str: "NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg",
+		Hex: "479f66c8be895830547e70b4b298cafd433dba6e",
+	},
+}
+
+//
+// Functions
+//
+
+// Test CB58 encoding/decoding
+func TestCB58(t *testing.T) {
+	for _, currTest := range append(testVectCB58, testVectID...) {
+		data, _ := hex.DecodeString(currTest.Hex)
+
+		if enc := EncodeCB58(data); enc != currTest.Str {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Str, enc)
+		}
+
+		dec, err := DecodeCB58(currTest.Str)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Str, err.Error())
+		} else if !bytes.Equal(dec, data) {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Str, currTest.Hex, dec)
+		}
+	}
+}
+
+// Test IDs
+func TestID(t *testing.T) {
+	for _, currTest := range testVectID {
+		id, err := DecodeID(currTest.Str)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Str, err.Error())
+			continue
+		}
+		if hex.EncodeToString(id[:]) != currTest.Hex {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Str, currTest.Hex, id[:])
+		}
+		if id.String() != currTest.Str {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Str, id.String())
+		}
+	}
+}
+
+// Test node IDs
+func TestNodeID(t *testing.T) {
+	for _, currTest := range testVectNodeID {
+		nodeID, err := DecodeNodeID(currTest.Str)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Str, err.Error())
+			continue
+		}
+		if hex.EncodeToString(nodeID[:]) != currTest.Hex {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Str, currTest.Hex, nodeID[:])
+		}
+		if nodeID.String() != currTest.Str {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Str, nodeID.String())
+		}
+	}
+}
+
+// Test invalid inputs
+func TestInvalid(t *testing.T) {
+	// Invalid checksum
+	if _, err := DecodeCB58("1NVSVezva3bAtJesnUk"); err != base58.ErrInvalidChecksum {
+		t.Errorf("Decoding CB58 with invalid checksum returned wrong error")
+	}
+	// Double SHA256 checksum is not valid for CB58
+	data, _ := hex.DecodeString(testVectCB58[0].Hex)
+	if _, err := DecodeCB58(base58.New(base58.AlphabetBitcoin).CheckEncode(data)); err != base58.ErrInvalidChecksum {
+		t.Errorf("Decoding CB58 with double SHA256 checksum returned wrong error")
+	}
+	// Too short
+	if _, err := DecodeCB58("111"); err != base58.ErrInputTooShort {
+		t.Errorf("Decoding too short CB58 returned wrong error")
+	}
+	// Invalid character
+	if _, err := DecodeCB58("0NVSVezva3bAtJesnUj"); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding CB58 with invalid character returned wrong error")
+	}
+
+	// Invalid lengths
+	if _, err := DecodeID(testVectNodeID[0].Str[len(NodeIDPrefix):]); err != ErrInvalidLength {
+		t.Errorf("Decoding node ID as ID returned wrong error")
+	}
+	if _, err := DecodeNodeID(NodeIDPrefix + testVectID[1].Str); err != ErrInvalidLength {
+		t.Errorf("Decoding ID as node ID returned wrong error")
+	}
+
+	// Invalid node ID prefix
+	for _, nodeIDStr := range []string { testVectNodeID[0].Str[len(NodeIDPrefix):], "nodeid-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg", "" } {
+		if _, err := DecodeNodeID(nodeIDStr); err != ErrInvalidNodeIDPrefix {
+			t.Errorf("Decoding node ID without prefix (%s) returned wrong error", nodeIDStr)
+		}
+	}
+}
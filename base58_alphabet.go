@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the alphabet type for base58 package.
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"errors"
+)
+
+//
+// Constants
+//
+const (
+	// Alphabet length
+	alphabetLen = 58
+	// Value in the decode table for characters not belonging to the alphabet
+	invalidCharIdx = 0xff
+	// Name of custom alphabets
+	customAlphabetName = "Custom"
+)
+
+//
+// Variables
+//
+var (
+	// ErrAlphabetLength is returned when creating an alphabet whose length is not 58 characters
+	ErrAlphabetLength = errors.New("The alphabet shall be 58 characters long")
+	// ErrAlphabetNotAscii is returned when creating an alphabet containing non-ASCII characters
+	ErrAlphabetNotAscii = errors.New("The alphabet shall only contain ASCII characters")
+	// ErrAlphabetDuplicatedChar is returned when creating an alphabet containing the same character more than once
+	ErrAlphabetDuplicatedChar = errors.New("The alphabet shall not contain duplicated characters")
+)
+
+//
+// Types
+//
+
+// Alphabet structure. It holds the alphabet name and characters, together with the precomputed
+// encode table (from index to character) and decode table (from character to index).
+type Alphabet struct {
+	name     string
+	encTable [alphabetLen]byte
+	decTable [256]byte
+	// True if built by NewNamedAlphabet, false for zero-value alphabets
+	built    bool
+}
+
+//
+// Exported functions
+//
+
+// Create a new alphabet from the specified 58-character string.
+// The alphabet is named "Custom", use NewNamedAlphabet for specifying a different name.
+func NewAlphabet(chars string) (*Alphabet, error) {
+	return NewNamedAlphabet(customAlphabetName, chars)
+}
+
+// Create a new alphabet with the specified name from the specified 58-character string.
+func NewNamedAlphabet(name string, chars string) (*Alphabet, error) {
+	if len(chars) != alphabetLen {
+		return nil, ErrAlphabetLength
+	}
+
+	alph := &Alphabet{ name: name }
+	for i := range alph.decTable {
+		alph.decTable[i] = invalidCharIdx
+	}
+
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c >= 0x80 {
+			return nil, ErrAlphabetNotAscii
+		}
+		if alph.decTable[c] != invalidCharIdx {
+			return nil, ErrAlphabetDuplicatedChar
+		}
+		alph.encTable[i] = c
+		alph.decTable[c] = byte(i)
+	}
+	alph.built = true
+
+	return alph, nil
+}
+
+// Get the index of the specified character in the alphabet, or -1 if the character does not belong to it.
+// The lookup is performed in constant time using the precomputed decode table.
+func (alph *Alphabet) Index(c byte) int {
+	idx := alph.decTable[c]
+	if idx == invalidCharIdx {
+		return -1
+	}
+	return int(idx)
+}
+
+// Get the alphabet name.
+func (alph *Alphabet) Name() string {
+	return alph.name
+}
+
+// Get the alphabet characters as string.
+func (alph *Alphabet) String() string {
+	return string(alph.encTable[:])
+}
+
+//
+// Not-exported functions
+//
+
+// Create a new alphabet from the specified string, panicking if not valid.
+// It shall only be used for built-in alphabets.
+func mustNewAlphabet(name string, chars string) *Alphabet {
+	alph, err := NewNamedAlphabet(name, chars)
+	if err != nil {
+		panic(err)
+	}
+	return alph
+}
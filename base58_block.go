@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the fixed-size block encoding/decoding for base58 package.
+// Data is split in blocks of 8 bytes, each one encoded independently to exactly 11 characters,
+// left-padded with the first alphabet character. The last block can be partial and it is encoded
+// to the minimum number of characters able to represent it.
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"errors"
+	"math/bits"
+)
+
+//
+// Constants
+//
+const (
+	// Size in bytes of a full block
+	blockSize = 8
+	// Size in characters of an encoded full block
+	encodedBlockSize = 11
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidBlockLen is returned when trying to decode a block whose length is not valid
+	ErrInvalidBlockLen = errors.New("The length of the specified encoded block is not valid")
+	// ErrInvalidBlock is returned when trying to decode a block whose value exceeds the block size (i.e. not canonical)
+	ErrInvalidBlock = errors.New("The specified encoded block is not canonical")
+
+	// Encoded size of a block, from its size
+	encodedBlockSizes = [blockSize + 1]int { 0, 2, 3, 5, 6, 7, 9, 10, 11 }
+)
+
+//
+// Exported functions
+//
+
+// Encode the specified bytes to Base58 format using fixed-size blocks (e.g. for Monero addresses).
+// Every 8 bytes are encoded to exactly 11 characters, the last partial block to the minimum number of characters.
+// It returns an empty string if the alphabet is not valid, use BlockEncodeE for getting the error.
+func (obj *Base58Obj) BlockEncode(input []byte) string {
+	enc, _ := obj.BlockEncodeE(input)
+	return enc
+}
+
+// Encode the specified bytes to Base58 format using fixed-size blocks, returning error if the alphabet is not valid.
+func (obj *Base58Obj) BlockEncodeE(input []byte) (string, error) {
+	// Get alphabet
+	alph, err := obj.getAlphabet()
+	if err != nil {
+		return "", err
+	}
+
+	encLen := len(input) / blockSize * encodedBlockSize + encodedBlockSizes[len(input) % blockSize]
+	return string(appendEncodeBlocks(make([]byte, 0, encLen), input, alph)), nil
+}
+
+// Decode the specified string in Base58 format using fixed-size blocks (e.g. for Monero addresses).
+// It returns ErrInvalidBlockLen if the last block length is not valid and ErrInvalidBlock if a block
+// is not canonical, i.e. its value does not fit the block size.
+func (obj *Base58Obj) BlockDecode(input string) ([]byte, error) {
+	// Get alphabet
+	alph, err := obj.getAlphabet()
+	if err != nil {
+		return nil, err
+	}
+
+	dec := make([]byte, (len(input) / encodedBlockSize + 1) * blockSize)
+	n, err := decodeBlocks(dec, input, 0, alph)
+	if err != nil {
+		return nil, err
+	}
+
+	return dec[:n], nil
+}
+
+//
+// Not-exported functions
+//
+
+// Append the block encoding of the specified bytes to dst using the specified alphabet
+func appendEncodeBlocks(dst []byte, src []byte, alph *Alphabet) []byte {
+	for len(src) > 0 {
+		blockLen := blockSize
+		if len(src) < blockLen {
+			blockLen = len(src)
+		}
+		dst = appendEncodeBlock(dst, src[:blockLen], alph)
+		src = src[blockLen:]
+	}
+
+	return dst
+}
+
+// Append the encoding of a single block (up to 8 bytes) to dst using the specified alphabet
+func appendEncodeBlock(dst []byte, block []byte, alph *Alphabet) []byte {
+	// Get block value
+	var val uint64
+	for _, b := range block {
+		val = (val << 8) | uint64(b)
+	}
+
+	// Grow slice for encoded block
+	encLen := encodedBlockSizes[len(block)]
+	dstLen := len(dst)
+	dst = growByteSlice(dst, encLen)
+	enc := dst[dstLen:]
+
+	// Get encoding bytes, padded with the first alphabet character
+	for i := encLen - 1; i >= 0; i-- {
+		enc[i] = alph.encTable[val % 58]
+		val /= 58
+	}
+
+	return dst
+}
+
+// Decode the specified block-encoded string into dst using the specified alphabet.
+// The offset is added to the offset of invalid characters, if any.
+// It returns the number of bytes written to dst, which shall be big enough.
+func decodeBlocks(dst []byte, input string, offset int, alph *Alphabet) (int, error) {
+	decLen := 0
+	for i := 0; i < len(input); i += encodedBlockSize {
+		blockEnd := i + encodedBlockSize
+		if blockEnd > len(input) {
+			blockEnd = len(input)
+		}
+
+		n, err := decodeBlock(dst[decLen:], input, i, blockEnd, alph)
+		if err != nil {
+			if charErr, ok := err.(InvalidCharacterError); ok {
+				charErr.Offset += offset
+				return decLen, charErr
+			}
+			return decLen, err
+		}
+		decLen += n
+	}
+
+	return decLen, nil
+}
+
+// Decode the block between the specified indexes of the input string into dst using the specified alphabet.
+// It returns the number of bytes written to dst.
+func decodeBlock(dst []byte, input string, start int, end int, alph *Alphabet) (int, error) {
+	// Get block size from the encoded size
+	blockLen := getBlockLen(end - start)
+	if blockLen == -1 {
+		return 0, ErrInvalidBlockLen
+	}
+
+	// Get block value, checking for overflows
+	var val uint64
+	for k := start; k < end; k++ {
+		// Find character in the alphabet
+		chrIdx := alph.decTable[input[k]]
+		// Format error if not found
+		if chrIdx == invalidCharIdx {
+			return 0, newInvalidCharacterError(input, k, alph)
+		}
+		// Update value: val = val * 58 + chrIdx
+		hi, lo := bits.Mul64(val, 58)
+		var c uint64
+		val, c = bits.Add64(lo, uint64(chrIdx), 0)
+		if hi != 0 || c != 0 {
+			return 0, ErrInvalidBlock
+		}
+	}
+	// The value shall fit the block size
+	if blockLen < blockSize && val >> uint(blockLen * 8) != 0 {
+		return 0, ErrInvalidBlock
+	}
+
+	// Put block bytes in big-endian order
+	for i := blockLen - 1; i >= 0; i-- {
+		dst[i] = byte(val)
+		val >>= 8
+	}
+
+	return blockLen, nil
+}
+
+// Get the block size from the encoded block size, or -1 if not valid
+func getBlockLen(encLen int) int {
+	for blockLen, currEncLen := range encodedBlockSizes {
+		if currEncLen == encLen {
+			return blockLen
+		}
+	}
+	return -1
+}
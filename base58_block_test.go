@@ -0,0 +1,251 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package base58
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Block test entry structure
+type testBlockEntry struct {
+	Hex string
+	Enc string
+}
+
+//
+// Variables
+//
+
+// Test vector for block encoding (Bitcoin alphabet, same of Monero)
+var testVectBlock = []testBlockEntry {
+	testBlockEntry {
+		Hex: "",
+		Enc: "",
+	},
+	testBlockEntry {
+		Hex: "00",
+		Enc: "11",
+	},
+	testBlockEntry {
+		Hex: "39",
+		Enc: "1z",
+	},
+	testBlockEntry {
+		Hex: "ff",
+		Enc: "5Q",
+	},
+	testBlockEntry {
+		Hex: "0000",
+		Enc: "111",
+	},
+	testBlockEntry {
+		Hex: "0039",
+		Enc: "11z",
+	},
+	testBlockEntry {
+		Hex: "0100",
+		Enc: "15R",
+	},
+	testBlockEntry {
+		Hex: "ffff",
+		Enc: "LUv",
+	},
+	testBlockEntry {
+		Hex: "000000",
+		Enc: "11111",
+	},
+	testBlockEntry {
+		Hex: "000039",
+		Enc: "1111z",
+	},
+	testBlockEntry {
+		Hex: "010000",
+		Enc: "11LUw",
+	},
+	testBlockEntry {
+		Hex: "ffffff",
+		Enc: "2UzHL",
+	},
+	testBlockEntry {
+		Hex: "00000039",
+		Enc: "11111z",
+	},
+	testBlockEntry {
+		Hex: "ffffffff",
+		Enc: "7YXq9G",
+	},
+	testBlockEntry {
+		Hex: "0000000039",
+		Enc: "111111z",
+	},
+	testBlockEntry {
+		Hex: "ffffffffff",
+		Enc: "VtB5VXc",
+	},
+	testBlockEntry {
+		Hex: "000000000039",
+		Enc: "11111111z",
+	},
+	testBlockEntry {
+		Hex: "ffffffffffff",
+		Enc: "3CUsUpv9t",
+	},
+	testBlockEntry {
+		Hex: "00000000000039",
+		Enc: "111111111z",
+	},
+	testBlockEntry {
+		Hex: "ffffffffffffff",
+		Enc: "Ahg1opVcGW",
+	},
+	testBlockEntry {
+		Hex: "0000000000000039",
+		Enc: "1111111111z",
+	},
+	testBlockEntry {
+		Hex: "ffffffffffffffff",
+		Enc: "jpXCZedGfVQ",
+	},
+	testBlockEntry {
+		Hex: "06156013762879f7ffffffffff",
+		Enc: "22222222222VtB5VXc",
+	},
+	testBlockEntry {
+		Hex: "0000000000000000000000",
+		Enc: "1111111111111111",
+	},
+	testBlockEntry {
+		Hex: "00000000000000000000000000000000000000000000",
+		Enc: "1111111111111111111111111111111",
+	},
+}
+
+// Tests for block decoding errors
+var testVectBlockInvalid = map[string]error {
+	"1":                      ErrInvalidBlockLen,
+	"z":                      ErrInvalidBlockLen,
+	"1111":                   ErrInvalidBlockLen,
+	"11111111":               ErrInvalidBlockLen,
+	"111111111111":           ErrInvalidBlockLen,
+	"5R":                     ErrInvalidBlock,
+	"zz":                     ErrInvalidBlock,
+	"LUw":                    ErrInvalidBlock,
+	"2UzHM":                  ErrInvalidBlock,
+	"7YXq9H":                 ErrInvalidBlock,
+	"VtB5VXd":                ErrInvalidBlock,
+	"3CUsUpv9u":              ErrInvalidBlock,
+	"Ahg1opVcGX":             ErrInvalidBlock,
+	"jpXCZedGfVR":            ErrInvalidBlock,
+	"zzzzzzzzzzz":            ErrInvalidBlock,
+	"11111111111jpXCZedGfVR": ErrInvalidBlock,
+}
+
+//
+// Functions
+//
+
+// Test block encoding and decoding
+func TestBlock(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for _, currTest := range testVectBlock {
+		raw, _ := hex.DecodeString(currTest.Hex)
+
+		// BlockEncode
+		enc := base58Btc.BlockEncode(raw)
+		if enc != currTest.Enc {
+			t.Errorf("Block encoding was incorrect: expected %s, got: %s", currTest.Enc, enc)
+		}
+
+		// BlockDecode
+		dec, err := base58Btc.BlockDecode(currTest.Enc)
+		if err != nil {
+			t.Errorf("Block decoding (%s) returned error: %s", currTest.Enc, err.Error())
+		}
+		if bytes.Compare(dec, raw) != 0 {
+			t.Errorf("Block decoding was incorrect: expected %x, got: %x", raw, dec)
+		}
+	}
+}
+
+// Test block encoding and decoding of random data, for all alphabets
+func TestBlockRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	for alphIdx := range alphabetMap {
+		base58Obj := New(alphIdx)
+
+		for i := 0; i < testDiffRandNum; i++ {
+			raw := randomBytes(rnd)
+
+			dec, err := base58Obj.BlockDecode(base58Obj.BlockEncode(raw))
+			if err != nil {
+				t.Errorf("Block decoding returned error: %s", err.Error())
+			}
+			if bytes.Compare(dec, raw) != 0 {
+				t.Errorf("Block decoding was incorrect: expected %x, got: %x", raw, dec)
+			}
+		}
+	}
+}
+
+// Test block decoding errors
+func TestBlockInvalid(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for enc, expErr := range testVectBlockInvalid {
+		dec, err := base58Btc.BlockDecode(enc)
+		if dec != nil {
+			t.Errorf("Block decoding (%s) returned not-nil result", enc)
+		}
+		if err != expErr {
+			t.Errorf("Block decoding (%s) returned wrong error: expected %v, got: %v", enc, expErr, err)
+		}
+	}
+
+	// Invalid character
+	_, err := base58Btc.BlockDecode("111111111110I")
+	if !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Block decoding with invalid character returned wrong error")
+	}
+
+	// Invalid alphabet
+	if enc := New(3).BlockEncode([]byte("test")); enc != "" {
+		t.Errorf("Block encoding with invalid alphabet returned not-empty result")
+	}
+	if enc, err := New(3).BlockEncodeE([]byte("test")); enc != "" || err != ErrInvalidAlphabet {
+		t.Errorf("Block encoding with invalid alphabet returned wrong error")
+	}
+	if _, err = New(3).BlockDecode("test"); err != ErrInvalidAlphabet {
+		t.Errorf("Block decoding with invalid alphabet returned wrong error")
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the checksum algorithms for base58 package.
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/ebellocchia/go-base58/internal/blake2b"
+	"github.com/ebellocchia/go-base58/internal/keccak"
+)
+
+//
+// Constants
+//
+const (
+	// Take the checksum from the first bytes of the digest
+	ChecksumHead ChecksumPosition = 0
+	// Take the checksum from the last bytes of the digest
+	ChecksumTail ChecksumPosition = 1
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidChecksumSize is returned when creating a checksum with a size not fitting the digest
+	ErrInvalidChecksumSize = errors.New("The checksum size is not valid for the digest")
+
+	// ChecksumDoubleSha256 is the first 4 bytes of the double SHA256 (Bitcoin, Ripple, Tron, ...).
+	// It is the default checksum.
+	ChecksumDoubleSha256 Checksum = mustNewHashChecksum(doubleSha256, nil, checksumLen, ChecksumHead)
+	// ChecksumSha256Tail is the last 4 bytes of the single SHA256 (Avalanche CB58)
+	ChecksumSha256Tail Checksum = mustNewHashChecksum(sha256Hash, nil, checksumLen, ChecksumTail)
+	// ChecksumKeccak256 is the first 4 bytes of the Keccak-256 (Monero)
+	ChecksumKeccak256 Checksum = mustNewHashChecksum(keccak256, nil, checksumLen, ChecksumHead)
+	// ChecksumBlake2b512 is the first 2 bytes of the BLAKE2b-512 of "SS58PRE" followed by the data (Substrate SS58, for 32-byte account IDs)
+	ChecksumBlake2b512 Checksum = mustNewHashChecksum(blake2b512, []byte("SS58PRE"), 2, ChecksumHead)
+)
+
+//
+// Types
+//
+
+// Checksum is the interface implemented by the checksum algorithms used by CheckEncode and CheckDecode.
+type Checksum interface {
+	// Get the checksum size in bytes
+	Size() int
+	// Compute the checksum of the specified data, it shall be Size() bytes long
+	Compute(data []byte) []byte
+}
+
+// Position of the checksum bytes in the digest.
+type ChecksumPosition int
+
+// Hash checksum structure.
+// The checksum is computed by hashing the prefix followed by the data, and taking
+// the first or last bytes of the digest.
+type HashChecksum struct {
+	hashFn func([]byte) []byte
+	prefix []byte
+	size   int
+	pos    ChecksumPosition
+}
+
+//
+// Exported functions
+//
+
+// Create a new hash checksum from the specified hash function, size and position.
+// It returns ErrInvalidChecksumSize if size is not positive or it is greater than the digest size.
+func NewHashChecksum(hashFn func(data []byte) []byte, size int, pos ChecksumPosition) (*HashChecksum, error) {
+	return NewPrefixedHashChecksum(hashFn, nil, size, pos)
+}
+
+// Create a new hash checksum from the specified hash function, prefix, size and position.
+// The prefix is hashed before the data.
+// It returns ErrInvalidChecksumSize if size is not positive or it is greater than the digest size.
+func NewPrefixedHashChecksum(hashFn func(data []byte) []byte, prefix []byte, size int, pos ChecksumPosition) (*HashChecksum, error) {
+	if size <= 0 || size > len(hashFn(nil)) {
+		return nil, ErrInvalidChecksumSize
+	}
+
+	return &HashChecksum {
+		hashFn: hashFn,
+		prefix: append([]byte(nil), prefix...),
+		size:   size,
+		pos:    pos,
+	}, nil
+}
+
+// Get the checksum size in bytes.
+func (chksum *HashChecksum) Size() int {
+	return chksum.size
+}
+
+// Compute the checksum of the specified data.
+func (chksum *HashChecksum) Compute(data []byte) []byte {
+	// Prepend prefix, if any
+	if len(chksum.prefix) > 0 {
+		data = append(append(make([]byte, 0, len(chksum.prefix) + len(data)), chksum.prefix...), data...)
+	}
+
+	// Take the checksum from the digest
+	digest := chksum.hashFn(data)
+	if chksum.pos == ChecksumTail {
+		return digest[len(digest) - chksum.size:]
+	}
+	return digest[:chksum.size]
+}
+
+//
+// Not-exported functions
+//
+
+// Create a new hash checksum, panicking if not valid.
+// It shall only be used for built-in checksums.
+func mustNewHashChecksum(hashFn func([]byte) []byte, prefix []byte, size int, pos ChecksumPosition) *HashChecksum {
+	chksum, err := NewPrefixedHashChecksum(hashFn, prefix, size, pos)
+	if err != nil {
+		panic(err)
+	}
+	return chksum
+}
+
+// Compute the double SHA256 of the specified byte slice
+func doubleSha256(slice []byte) []byte {
+	hash1 := sha256.Sum256(slice)
+	hash2 := sha256.Sum256(hash1[:])
+
+	return hash2[:]
+}
+
+// Compute the SHA256 of the specified byte slice
+func sha256Hash(slice []byte) []byte {
+	hash := sha256.Sum256(slice)
+	return hash[:]
+}
+
+// Compute the Keccak-256 of the specified byte slice
+func keccak256(slice []byte) []byte {
+	hash := keccak.Sum256(slice)
+	return hash[:]
+}
+
+// Compute the BLAKE2b-512 of the specified byte slice
+func blake2b512(slice []byte) []byte {
+	hash := blake2b.Sum512(slice)
+	return hash[:]
+}
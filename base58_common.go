@@ -28,9 +28,7 @@ package base58
 // Imports
 //
 import (
-	"crypto/sha256"
 	"errors"
-	"math/big"
 )
 
 //
@@ -41,21 +39,29 @@ const (
 	AlphabetBitcoin = 0
 	AlphabetRipple  = 1
 	AlphabetFlickr  = 2
-	// Checksum length
+	// Default checksum length
 	checksumLen = 4
+	// Number of base58 digits in a limb
+	limbDigits = 10
+	// Radix of a limb (58^10), the biggest power of 58 fitting in a uint64
+	limbRadix = 430804206899405824
+	// Number of limbs allocated on the stack
+	stackLimbsNum = 128
 )
 
 //
 // Variables
 //
 var (
-	// Base58 radix as big.Int
-	bigRadix = big.NewInt(58)
-	// Map from alphabet index to alphabet string
-	alphabetMap = map[int]string {
-		AlphabetBitcoin : "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz",
-		AlphabetRipple  : "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz",
-		AlphabetFlickr  : "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ",
+	// Powers of 58 up to the limb radix
+	limbPowers = [limbDigits + 1]uint64 {
+		1, 58, 3364, 195112, 11316496, 656356768, 38068692544, 2207984167552, 128063081718016, 7427658739644928, limbRadix,
+	}
+	// Map from alphabet index to alphabet
+	alphabetMap = map[int]*Alphabet {
+		AlphabetBitcoin : mustNewAlphabet("Bitcoin", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"),
+		AlphabetRipple  : mustNewAlphabet("Ripple", "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"),
+		AlphabetFlickr  : mustNewAlphabet("Flickr", "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"),
 	}
 	// ErrInvalidAlphabet is returned when trying to get a not-existent alphabet
 	ErrInvalidAlphabet = errors.New("The specified alphabet is not existent")
@@ -67,8 +73,12 @@ var (
 
 // Base58 structure. It basically holds the alphabet index to be used.
 // The default value (0) is the Bitcoin alphabet.
+// If Alph is set, the custom alphabet is used and AlphIdx is ignored.
+// If Chksum is not set, the double SHA256 checksum is used by CheckEncode and CheckDecode.
 type Base58Obj struct {
 	AlphIdx int
+	Alph    *Alphabet
+	Chksum  Checksum
 }
 
 //
@@ -82,34 +92,64 @@ func New(alphIdx int) (*Base58Obj) {
 	}
 }
 
+// Helper function for creating Base58Obj structure from the alphabet index, by validating it.
+// It returns ErrInvalidAlphabet if the alphabet index is not valid.
+func NewE(alphIdx int) (*Base58Obj, error) {
+	if _, err := getAlphabet(alphIdx); err != nil {
+		return nil, err
+	}
+	return New(alphIdx), nil
+}
+
+// Helper function for creating Base58Obj structure from a custom alphabet.
+func NewWithAlphabet(alph *Alphabet) (*Base58Obj) {
+	return &Base58Obj {
+		Alph: alph,
+	}
+}
+
+// Get a copy of the base58 object using the specified checksum for CheckEncode and CheckDecode.
+func (obj *Base58Obj) WithChecksum(chksum Checksum) (*Base58Obj) {
+	newObj := *obj
+	newObj.Chksum = chksum
+
+	return &newObj
+}
+
+// Get the built-in alphabet from the specified alphabet index.
+func BuiltinAlphabet(alphIdx int) (*Alphabet, error) {
+	return getAlphabet(alphIdx)
+}
+
 //
 // Not-exported functions
 //
 
-// Get the alphabet string from the specified alphabet index
-func getAlphabet(alphIdx int) (string, error) {
-	alph, ok := alphabetMap[alphIdx]
-	if !ok {
-		return "", ErrInvalidAlphabet
+// Get the alphabet of the base58 object.
+// Custom alphabets not created by NewAlphabet or NewNamedAlphabet are not valid.
+func (obj *Base58Obj) getAlphabet() (*Alphabet, error) {
+	if obj.Alph != nil {
+		if !obj.Alph.built {
+			return nil, ErrInvalidAlphabet
+		}
+		return obj.Alph, nil
 	}
-	return alph, nil
+	return getAlphabet(obj.AlphIdx)
 }
 
-// Compute Base58 checksum.
-// The checksum is defined as the first 4-byte of the double SHA256 of the input.
-func computeCheckum(slice []byte) (chksum [checksumLen]byte) {
-	// Compute the double SHA256
-	hash := doubleSha256(slice)
-	// The first 4-byte of the hash is the checksum
-	copy(chksum[:], hash[:checksumLen])
-
-	return chksum
+// Get the checksum of the base58 object
+func (obj *Base58Obj) getChecksum() Checksum {
+	if obj.Chksum != nil {
+		return obj.Chksum
+	}
+	return ChecksumDoubleSha256
 }
 
-// Compute the double SHA256 of the specified byte slice
-func doubleSha256(slice []byte) []byte {
-	hash1 := sha256.Sum256(slice)
-	hash2 := sha256.Sum256(hash1[:])
-
-	return hash2[:]
+// Get the alphabet from the specified alphabet index
+func getAlphabet(alphIdx int) (*Alphabet, error) {
+	alph, ok := alphabetMap[alphIdx]
+	if !ok {
+		return nil, ErrInvalidAlphabet
+	}
+	return alph, nil
 }
@@ -30,8 +30,9 @@ package base58
 import (
 	"bytes"
 	"errors"
-	"math/big"
-	"strings"
+	"fmt"
+	"math/bits"
+	"unicode/utf8"
 )
 
 //
@@ -42,45 +43,77 @@ var (
 	ErrInvalidFormat = errors.New("The specified string is not a valid Base58 format")
 	// ErrInvalidChecksum is returned when trying to decode a string with invalid checksum
 	ErrInvalidChecksum = errors.New("The checksum of the specified string is not valid")
+	// ErrBufferTooSmall is returned when trying to decode a string into a too small buffer
+	ErrBufferTooSmall = errors.New("The destination buffer is too small for the decoded bytes")
+	// ErrInputTooShort is returned when trying to decode a string too short for containing the checksum (and version, if any)
+	ErrInputTooShort = errors.New("The specified string is too short for containing the checksum and version")
+	// ErrInvalidVersionLen is returned when trying to decode a string with a negative version length
+	ErrInvalidVersionLen = errors.New("The specified version length is not valid")
 )
 
+//
+// Types
+//
+
+// InvalidCharacterError is returned when trying to decode a string containing a character not belonging to the alphabet.
+// It reports the byte offset of the character in the string, the character itself and the alphabet name.
+// It matches ErrInvalidFormat when using errors.Is.
+type InvalidCharacterError struct {
+	Offset   int
+	Char     rune
+	Alphabet string
+}
+
 //
 // Exported functions
 //
 
+// Get the error message.
+func (e InvalidCharacterError) Error() string {
+	return fmt.Sprintf("Invalid character %q at offset %d for %s alphabet", e.Char, e.Offset, e.Alphabet)
+}
+
+// Report whether the error matches the target, for errors.Is.
+// An invalid character error always matches ErrInvalidFormat.
+func (e InvalidCharacterError) Is(target error) bool {
+	return target == ErrInvalidFormat
+}
+
 // Decode the specified string in Base58 format to bytes.
 func (obj *Base58Obj) Decode(input string) ([]byte, error) {
 	// Get alphabet
-	alphabet, err := getAlphabet(obj.AlphIdx)
+	alph, err := obj.getAlphabet()
 	if err != nil {
 		return nil, err
 	}
 
-	decVal := big.NewInt(0)
-
-	// Convert the string back to big integer
-	mult := big.NewInt(1)
-	tmp  := new(big.Int)
+	return decode(input, alph)
+}
 
-	for i := len(input) - 1; i >= 0; i-- {
-		// Find character in the alphabet
-		chrIdx := strings.IndexByte(alphabet, input[i])
-		// Format error if not found
-		if chrIdx == -1 {
-			return nil, ErrInvalidFormat
-		}
-		// Update value: val += mult * chrIdx
-		tmp.SetInt64(int64(chrIdx))
-		tmp.Mul(mult, tmp)
-		decVal.Add(decVal, tmp)
-		// Increase multiplier: mult = mult * 58
-		mult.Mul(mult, bigRadix)
+// Append the decoding of the specified string in Base58 format to dst and return the extended slice.
+// No allocation is performed if dst has enough capacity (see MaxDecodedLen) and src is not longer than ~1400 characters.
+// In case of error, dst is returned unchanged.
+func (obj *Base58Obj) AppendDecode(dst []byte, src string) ([]byte, error) {
+	// Get alphabet
+	alph, err := obj.getAlphabet()
+	if err != nil {
+		return dst, err
 	}
 
-	// Pad decoding depending on the number of the first alphabet letter
-	dec := padDecoding(decVal.Bytes(), input, alphabet)
+	return appendDecode(dst, src, alph)
+}
 
-	return dec, nil
+// Decode the specified string in Base58 format into dst and return the number of written bytes.
+// It returns ErrBufferTooSmall if dst is not big enough for the decoded bytes.
+// No allocation is performed if src is not longer than ~1400 characters.
+func (obj *Base58Obj) DecodeInto(dst []byte, src string) (int, error) {
+	// Get alphabet
+	alph, err := obj.getAlphabet()
+	if err != nil {
+		return 0, err
+	}
+
+	return decodeInto(dst, src, alph)
 }
 
 // Decode the specified string in Base58 format to bytes, by removing and verifying the checksum.
@@ -91,42 +124,221 @@ func (obj *Base58Obj) CheckDecode(input string) ([]byte, error) {
 		return nil, err
 	}
 
+	chksum := obj.getChecksum()
+
+	// Check length
+	if len(dec) < chksum.Size() {
+		return nil, ErrInputTooShort
+	}
+
 	// Get data and checksum parts
-	chksumIdx := len(dec) - checksumLen
+	chksumIdx := len(dec) - chksum.Size()
 	chksumPart, dataPart := dec[chksumIdx:], dec[:chksumIdx]
 
 	// Compute again checksum on data
-	compChksum := computeCheckum(dataPart)
+	compChksum := chksum.Compute(dataPart)
 
 	// Verify checksum
-	if bytes.Compare(chksumPart, compChksum[:]) != 0 {
+	if bytes.Compare(chksumPart, compChksum) != 0 {
 		return nil, ErrInvalidChecksum
 	}
 
 	return dataPart, nil
 }
 
+// Decode the specified string in Base58 format to version and payload, by removing and verifying the checksum.
+// The version is the first versionLen bytes of the decoded data.
+func (obj *Base58Obj) CheckDecodeVersion(input string, versionLen int) (version []byte, payload []byte, err error) {
+	if versionLen < 0 {
+		return nil, nil, ErrInvalidVersionLen
+	}
+
+	// Decode string
+	dec, err := obj.CheckDecode(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check length
+	if len(dec) < versionLen {
+		return nil, nil, ErrInputTooShort
+	}
+
+	// Limit version capacity, so that appending to it does not overwrite the payload
+	return dec[:versionLen:versionLen], dec[versionLen:], nil
+}
+
+// Get the maximum length of the decoding of a Base58 string of n characters.
+// The bound is reached when all the characters are the first alphabet character, since each of them is decoded to a zero byte.
+// Otherwise, each character takes ~0.7322 bytes.
+func MaxDecodedLen(n int) int {
+	return n
+}
+
 //
 // Not-exported functions
 //
 
-// Pad decoding by adding zeros as many times as the number of leading first alphabet characters in the original string.
-func padDecoding(dec []byte, input string, alphabet string) []byte {
-	// Compute the number of zeros to be added
-	zerosCnt := countLeadingFirstAlphChar(input, alphabet)
+// Decode the specified string in Base58 format to bytes using the specified alphabet.
+func decode(input string, alph *Alphabet) ([]byte, error) {
+	dec, err := appendDecode(make([]byte, 0, len(input)), input, alph)
+	if err != nil {
+		return nil, err
+	}
+
+	return dec, nil
+}
+
+// Append the decoding of the specified string in Base58 format to dst using the specified alphabet.
+func appendDecode(dst []byte, input string, alph *Alphabet) ([]byte, error) {
+	// Limbs in base 2^64, least significant first
+	var limbsBuf [stackLimbsNum]uint64
+	limbs := limbsBuf[:]
+	if limbsNum := getDecodedLength(input) / 8 + 1; limbsNum > len(limbs) {
+		limbs = make([]uint64, limbsNum)
+	}
 
-	// Pad bytes with zeros
-	decPadded := make([]byte, len(dec) + zerosCnt)
-	copy(decPadded[zerosCnt:], dec)
+	zerosCnt, limbsLen, err := stringToLimbs(input, limbs, alph)
+	if err != nil {
+		return dst, err
+	}
+
+	// Grow slice for decoded output
+	dstLen := len(dst)
+	dst = growByteSlice(dst, zerosCnt + getLimbsBytesNum(limbs[:limbsLen]))
+	dec := dst[dstLen:]
+
+	// Pad decoding depending on the number of the first alphabet letter
+	for i := 0; i < zerosCnt; i++ {
+		dec[i] = 0
+	}
+	putLimbsBytes(dec[zerosCnt:], limbs[:limbsLen])
+
+	return dst, nil
+}
+
+// Decode the specified string in Base58 format into dst using the specified alphabet.
+// It returns the number of bytes written to dst.
+func decodeInto(dst []byte, input string, alph *Alphabet) (int, error) {
+	// Limbs in base 2^64, least significant first
+	var limbsBuf [stackLimbsNum]uint64
+	limbs := limbsBuf[:]
+	if limbsNum := getDecodedLength(input) / 8 + 1; limbsNum > len(limbs) {
+		limbs = make([]uint64, limbsNum)
+	}
+
+	zerosCnt, limbsLen, err := stringToLimbs(input, limbs, alph)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check destination length
+	decLen := zerosCnt + getLimbsBytesNum(limbs[:limbsLen])
+	if decLen > len(dst) {
+		return 0, ErrBufferTooSmall
+	}
+
+	// Pad decoding depending on the number of the first alphabet letter
+	for i := 0; i < zerosCnt; i++ {
+		dst[i] = 0
+	}
+	putLimbsBytes(dst[zerosCnt:decLen], limbs[:limbsLen])
+
+	return decLen, nil
+}
+
+// Convert the specified string in Base58 format to limbs in base 2^64, least significant first.
+// The input is processed up to 10 characters at a time, so that all the arithmetic is performed on machine words.
+// It returns the number of leading first alphabet characters and the number of used limbs.
+func stringToLimbs(input string, limbs []uint64, alph *Alphabet) (int, int, error) {
+	// Leading first alphabet characters are decoded separately
+	zerosCnt := countLeadingFirstAlphChar(input, alph.encTable[0])
+	data := input[zerosCnt:]
+	limbsLen := 0
+
+	// The first chunk is partial if the length is not a multiple of 10
+	chunkLen := len(data) % limbDigits
+	if chunkLen == 0 {
+		chunkLen = limbDigits
+	}
+
+	for i := 0; i < len(data); i, chunkLen = i + chunkLen, limbDigits {
+		// Get chunk value
+		var chunk uint64
+		for k := i; k < i + chunkLen; k++ {
+			// Find character in the alphabet
+			chrIdx := alph.decTable[data[k]]
+			// Format error if not found
+			if chrIdx == invalidCharIdx {
+				return 0, 0, newInvalidCharacterError(input, zerosCnt + k, alph)
+			}
+			chunk = chunk * 58 + uint64(chrIdx)
+		}
+
+		// Update value: limbs = limbs * 58^chunkLen + chunk
+		mult := limbPowers[chunkLen]
+		carry := chunk
+		for j := 0; j < limbsLen; j++ {
+			hi, lo := bits.Mul64(limbs[j], mult)
+			var c uint64
+			limbs[j], c = bits.Add64(lo, carry, 0)
+			carry = hi + c
+		}
+		if carry > 0 {
+			limbs[limbsLen] = carry
+			limbsLen++
+		}
+	}
+
+	return zerosCnt, limbsLen, nil
+}
+
+// Get the number of bytes of the specified limbs, without the leading zeros of the most significant one
+func getLimbsBytesNum(limbs []uint64) int {
+	if len(limbs) == 0 {
+		return 0
+	}
+	return (len(limbs) - 1) * 8 + (bits.Len64(limbs[len(limbs) - 1]) + 7) / 8
+}
+
+// Put the specified limbs to the specified slice in big-endian order, starting from the end of the slice
+func putLimbsBytes(slice []byte, limbs []uint64) {
+	pos := len(slice) - 1
+	for _, limb := range limbs {
+		for k := 0; k < 8 && pos >= 0; k++ {
+			slice[pos] = byte(limb)
+			limb >>= 8
+			pos--
+		}
+	}
+}
+
+// Create a new invalid character error for the character at the specified offset
+func newInvalidCharacterError(input string, offset int, alph *Alphabet) InvalidCharacterError {
+	char, size := utf8.DecodeRuneInString(input[offset:])
+	// Report the raw byte if it is not valid UTF-8
+	if size == 1 && char == utf8.RuneError {
+		char = rune(input[offset])
+	}
+
+	return InvalidCharacterError {
+		Offset:   offset,
+		Char:     char,
+		Alphabet: alph.Name(),
+	}
+}
 
-	return decPadded
+// Compute the decoded length from the input string.
+// By definition, the decoded length is ~73% of input length.
+func getDecodedLength(input string) int {
+	return (len(input) * 733 / 1000) + 1
 }
 
 // Count the number of leading first alphabet characters.
-func countLeadingFirstAlphChar(input string, alphabet string) int {
+func countLeadingFirstAlphChar(input string, firstChar byte) int {
 	var charCnt int
 	for charCnt = 0; charCnt < len(input); charCnt++ {
-		if input[charCnt] != alphabet[0] {
+		if input[charCnt] != firstChar {
 			break;
 		}
 	}
@@ -28,99 +28,233 @@ package base58
 // Imports
 //
 import (
-	"math/big"
-)
-
-//
-// Variables
-//
-var (
-	// Zero as big.Int
-	bigZero = big.NewInt(0)
+	"math/bits"
 )
 
 //
 // Exported functions
 //
 
-// Encode the specified bytes to Base58 format
+// Encode the specified bytes to Base58 format.
+// It returns an empty string if the alphabet is not valid, use EncodeE for getting the error.
 func (obj *Base58Obj) Encode(input []byte) string {
+	enc, _ := obj.EncodeE(input)
+	return enc
+}
+
+// Encode the specified bytes to Base58 format, returning error if the alphabet is not valid.
+func (obj *Base58Obj) EncodeE(input []byte) (string, error) {
 	// Get alphabet
-	alphabet, err := getAlphabet(obj.AlphIdx)
+	alph, err := obj.getAlphabet()
 	if err != nil {
-		return ""
+		return "", err
 	}
 
-	// Create slice for encoded output
-	enc := make([]byte, 0, getOutputLength(input))
-
-	// Convert bytes to big integer
-	encVal := byteSliceToBigInt(input)
+	return encode(input, alph), nil
+}
 
-	// Get encoding bytes from integer
-	mod := new(big.Int)
-	for encVal.Cmp(bigZero) > 0 {
-		encVal.DivMod(encVal, bigRadix, mod)
-		enc = append(enc, alphabet[mod.Int64()])
+// Append the Base58 encoding of the specified bytes to dst and return the extended slice.
+// No allocation is performed if dst has enough capacity (see MaxEncodedLen) and src is not longer than ~900 bytes.
+// It returns dst unchanged if the alphabet is not valid.
+func (obj *Base58Obj) AppendEncode(dst []byte, src []byte) []byte {
+	// Get alphabet
+	alph, err := obj.getAlphabet()
+	if err != nil {
+		return dst
 	}
 
-	// Pad encoding depending on the number of initial zeros
-	enc = padEncoding(enc, input, alphabet)
-
-	// Reverse bytes slice
-	reverseByteSlice(enc)
-
-	// Convert to string
-	return string(enc)
+	return appendEncode(dst, src, alph)
 }
 
 // Encode the specified bytes to Base58 format, by adding the checksum.
+// It returns an empty string if the alphabet is not valid, use CheckEncodeE for getting the error.
 func (obj *Base58Obj) CheckEncode(input []byte) string {
+	enc, _ := obj.CheckEncodeE(input)
+	return enc
+}
+
+// Encode the specified bytes to Base58 format, by adding the checksum and returning error if the alphabet is not valid.
+func (obj *Base58Obj) CheckEncodeE(input []byte) (string, error) {
+	chksum := obj.getChecksum()
+
 	// Create slice for data with checksum
-	dataWithChksum := make([]byte, 0, len(input) + checksumLen)
+	dataWithChksum := make([]byte, 0, len(input) + chksum.Size())
 	dataWithChksum = append(dataWithChksum, input[:]...)
 
 	// Compute checksum and append it
-	chksum := computeCheckum(input)
-	dataWithChksum = append(dataWithChksum, chksum[:]...)
+	dataWithChksum = append(dataWithChksum, chksum.Compute(input)...)
 
 	// Encode the final slice
-	return obj.Encode(dataWithChksum)
+	return obj.EncodeE(dataWithChksum)
+}
+
+// Get the maximum length of the Base58 encoding of n bytes.
+// Each byte takes at most log58(256) ~= 1.3657 characters, leading zero bytes take exactly one character.
+func MaxEncodedLen(n int) int {
+	if n == 0 {
+		return 0
+	}
+	// n * 1.366 + 1, split to avoid overflows
+	return (n / 1000) * 1366 + (n % 1000) * 1366 / 1000 + 1
+}
+
+// Get the exact length of the Base58 encoding of the specified bytes.
+// It is as expensive as encoding, but it performs no allocation for inputs up to ~900 bytes.
+func EncodedLenExact(src []byte) int {
+	// Leading zeros are encoded separately
+	zerosCnt := countLeadingZeros(src)
+	data := src[zerosCnt:]
+
+	// Limbs in base 58^10, least significant first
+	var limbsBuf [stackLimbsNum]uint64
+	limbs := limbsBuf[:]
+	if limbsNum := MaxEncodedLen(len(data)) / limbDigits + 1; limbsNum > len(limbs) {
+		limbs = make([]uint64, limbsNum)
+	}
+	limbsLen := bytesToLimbs(data, limbs)
+
+	return zerosCnt + getLimbsDigitsNum(limbs[:limbsLen])
+}
+
+// Encode the specified version and payload to Base58 format, by adding the checksum.
+// The version can be any number of bytes (e.g. 1 byte for Bitcoin addresses, 4 bytes for BIP32 extended keys).
+// It returns an empty string if the alphabet is not valid, use CheckEncodeVersionE for getting the error.
+func (obj *Base58Obj) CheckEncodeVersion(version []byte, payload []byte) string {
+	enc, _ := obj.CheckEncodeVersionE(version, payload)
+	return enc
+}
+
+// Encode the specified version and payload to Base58 format, by adding the checksum and returning error if the alphabet is not valid.
+func (obj *Base58Obj) CheckEncodeVersionE(version []byte, payload []byte) (string, error) {
+	// Create slice for version and payload
+	data := make([]byte, 0, len(version) + len(payload))
+	data = append(data, version...)
+	data = append(data, payload...)
+
+	return obj.CheckEncodeE(data)
 }
 
 //
 // Not-exported functions
 //
 
-// Compute the Base58 output length from the input bytes.
-// By definition, the output length is ~138% of input length.
-func getOutputLength(slice []byte) int {
-	return (len(slice) * 138 / 100) + 1
+// Encode the specified bytes to Base58 format using the specified alphabet.
+func encode(input []byte, alph *Alphabet) string {
+	return string(appendEncode(make([]byte, 0, MaxEncodedLen(len(input))), input, alph))
 }
 
-// Reverse the specified byte slice
-func reverseByteSlice(slice []byte) {
-	for i, j := 0, len(slice) - 1; i < j; i, j = i + 1, j - 1 {
-		slice[i], slice[j] = slice[j], slice[i]
+// Append the Base58 encoding of the specified bytes to dst using the specified alphabet.
+// The input is converted to limbs in base 58^10, taking up to 8 bytes at a time, so that
+// all the arithmetic is performed on machine words.
+// Limbs are stored on the stack for inputs up to ~900 bytes, so no allocation is performed if dst is big enough.
+func appendEncode(dst []byte, input []byte, alph *Alphabet) []byte {
+	// Leading zeros are encoded separately
+	zerosCnt := countLeadingZeros(input)
+	data := input[zerosCnt:]
+
+	// Limbs in base 58^10, least significant first
+	var limbsBuf [stackLimbsNum]uint64
+	limbs := limbsBuf[:]
+	if limbsNum := MaxEncodedLen(len(data)) / limbDigits + 1; limbsNum > len(limbs) {
+		limbs = make([]uint64, limbsNum)
+	}
+	limbsLen := bytesToLimbs(data, limbs)
+	digitsNum := getLimbsDigitsNum(limbs[:limbsLen])
+
+	// Grow slice for encoded output
+	dstLen := len(dst)
+	dst = growByteSlice(dst, zerosCnt + digitsNum)
+	enc := dst[dstLen:]
+
+	// Pad encoding depending on the number of initial zeros
+	for i := 0; i < zerosCnt; i++ {
+		enc[i] = alph.encTable[0]
+	}
+
+	// Get encoding bytes from limbs, starting from the least significant one
+	pos := len(enc) - 1
+	for j := 0; j < limbsLen; j++ {
+		limb := limbs[j]
+		for k := 0; k < limbDigits && pos >= zerosCnt; k++ {
+			enc[pos] = alph.encTable[limb % 58]
+			limb /= 58
+			pos--
+		}
+	}
+
+	return dst
+}
+
+// Get the number of digits of the specified limbs in base 58^10, without the leading zeros of the most significant one
+func getLimbsDigitsNum(limbs []uint64) int {
+	if len(limbs) == 0 {
+		return 0
+	}
+
+	digitsNum := (len(limbs) - 1) * limbDigits
+	for limb := limbs[len(limbs) - 1]; limb > 0; limb /= 58 {
+		digitsNum++
 	}
+
+	return digitsNum
 }
 
-// Convert the specified byte slice to big.Int
-func byteSliceToBigInt(slice []byte) (*big.Int) {
-	bigVal := new(big.Int)
-	bigVal.SetBytes(slice)
+// Convert the specified bytes to limbs in base 58^10, least significant first.
+// It returns the number of used limbs.
+func bytesToLimbs(data []byte, limbs []uint64) int {
+	limbsLen := 0
+
+	// The first word is partial if the length is not a multiple of 8
+	wordLen := len(data) % 8
+	if wordLen == 0 {
+		wordLen = 8
+	}
+
+	for i := 0; i < len(data); i, wordLen = i + wordLen, 8 {
+		// Get word value
+		var word uint64
+		for _, b := range data[i:i + wordLen] {
+			word = (word << 8) | uint64(b)
+		}
 
-	return bigVal
+		// Update value: limbs = limbs * 2^shift + word
+		shift := uint(wordLen * 8)
+		carry := word
+		for j := 0; j < limbsLen; j++ {
+			hi, lo := limbs[j] >> (64 - shift), limbs[j] << shift
+			var c uint64
+			lo, c = bits.Add64(lo, carry, 0)
+			carry, limbs[j] = bits.Div64(hi + c, lo, limbRadix)
+		}
+		for ; carry > 0; limbsLen++ {
+			limbs[limbsLen] = carry % limbRadix
+			carry /= limbRadix
+		}
+	}
+
+	return limbsLen
 }
 
-// Pad encoding by adding the first alphabet letter as many times as the number of leading zeros in the original bytes.
-func padEncoding(enc []byte, input []byte, alphabet string) []byte {
-	for _, b := range(input) {
-		if b != 0 {
+// Count the number of leading zeros in the specified byte slice
+func countLeadingZeros(slice []byte) int {
+	var zerosCnt int
+	for zerosCnt = 0; zerosCnt < len(slice); zerosCnt++ {
+		if slice[zerosCnt] != 0 {
 			break
 		}
-		enc = append(enc, alphabet[0])
 	}
 
-	return enc
+	return zerosCnt
+}
+
+// Grow the specified byte slice by n bytes, reallocating it only if its capacity is not enough
+func growByteSlice(slice []byte, n int) []byte {
+	if len(slice) + n <= cap(slice) {
+		return slice[:len(slice) + n]
+	}
+
+	newSlice := make([]byte, len(slice) + n, 2 * cap(slice) + n)
+	copy(newSlice, slice)
+
+	return newSlice
 }
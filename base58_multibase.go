@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the multibase encoding/decoding for base58 package.
+// Multibase strings are prefixed by a character identifying the encoding: 'z' for base58btc
+// (Bitcoin alphabet) and 'Z' for base58flickr (Flickr alphabet).
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+//
+// Constants
+//
+const (
+	// Multibase prefix for base58btc
+	MultibasePrefixBitcoin = 'z'
+	// Multibase prefix for base58flickr
+	MultibasePrefixFlickr = 'Z'
+)
+
+//
+// Variables
+//
+var (
+	// ErrMultibaseAlphabet is returned when trying to multibase encode with an alphabet without multibase prefix
+	ErrMultibaseAlphabet = errors.New("The specified alphabet has no multibase prefix")
+	// ErrMultibasePrefix is returned when trying to multibase decode a string with an unknown prefix
+	ErrMultibasePrefix = errors.New("The multibase prefix of the specified string is not known")
+
+	// Map from alphabet index to multibase prefix
+	multibasePrefixMap = map[int]byte {
+		AlphabetBitcoin: MultibasePrefixBitcoin,
+		AlphabetFlickr:  MultibasePrefixFlickr,
+	}
+)
+
+//
+// Types
+//
+
+// MultibasePrefixError is returned when trying to multibase decode a string with an unknown (or missing) prefix.
+// It reports the prefix character, which is zero for empty strings.
+// It matches ErrMultibasePrefix when using errors.Is.
+type MultibasePrefixError struct {
+	Prefix rune
+}
+
+//
+// Exported functions
+//
+
+// Get the error message.
+func (e MultibasePrefixError) Error() string {
+	if e.Prefix == 0 {
+		return "Missing multibase prefix"
+	}
+	return fmt.Sprintf("Unknown multibase prefix %q", e.Prefix)
+}
+
+// Report whether the error matches the target, for errors.Is.
+// A multibase prefix error always matches ErrMultibasePrefix.
+func (e MultibasePrefixError) Is(target error) bool {
+	return target == ErrMultibasePrefix
+}
+
+// Encode the specified bytes to multibase format, using the specified built-in alphabet.
+// It returns ErrMultibaseAlphabet if the alphabet has no multibase prefix (only AlphabetBitcoin and AlphabetFlickr have one).
+func MultibaseEncode(alphIdx int, data []byte) (string, error) {
+	prefix, ok := multibasePrefixMap[alphIdx]
+	if !ok {
+		return "", ErrMultibaseAlphabet
+	}
+
+	alph, err := getAlphabet(alphIdx)
+	if err != nil {
+		return "", err
+	}
+
+	enc := make([]byte, 1, 1 + MaxEncodedLen(len(data)))
+	enc[0] = prefix
+	return string(appendEncode(enc, data, alph)), nil
+}
+
+// Decode the specified string in multibase format, returning the index of the detected alphabet and the decoded bytes.
+// It returns a MultibasePrefixError if the prefix is unknown. In case of invalid characters, the offset of the
+// returned InvalidCharacterError refers to the whole string (i.e. prefix included).
+func MultibaseDecode(input string) (int, []byte, error) {
+	alphIdx, err := getMultibaseAlphabet(input)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	alph, err := getAlphabet(alphIdx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	dec, err := decode(input[1:], alph)
+	if err != nil {
+		// Report offset in the whole string
+		if charErr, ok := err.(InvalidCharacterError); ok {
+			charErr.Offset++
+			err = charErr
+		}
+		return 0, nil, err
+	}
+
+	return alphIdx, dec, nil
+}
+
+//
+// Not-exported functions
+//
+
+// Get the alphabet index from the multibase prefix of the specified string
+func getMultibaseAlphabet(input string) (int, error) {
+	if len(input) == 0 {
+		return 0, MultibasePrefixError {}
+	}
+
+	for alphIdx, prefix := range multibasePrefixMap {
+		if input[0] == prefix {
+			return alphIdx, nil
+		}
+	}
+
+	prefix, size := utf8.DecodeRuneInString(input)
+	// Report the raw byte if it is not valid UTF-8
+	if size == 1 && prefix == utf8.RuneError {
+		prefix = rune(input[0])
+	}
+	return 0, MultibasePrefixError { Prefix: prefix }
+}
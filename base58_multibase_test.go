@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package base58
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Multibase test entry structure
+type testMultibaseEntry struct {
+	Str     string
+	AlphIdx int
+	Enc     string
+}
+
+//
+// Variables
+//
+
+// Test vector for multibase (from multiformats/multibase)
+var testVectMultibase = []testMultibaseEntry {
+	testMultibaseEntry {
+		Str:     "",
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "z",
+	},
+	testMultibaseEntry {
+		Str:     "",
+		AlphIdx: AlphabetFlickr,
+		Enc:     "Z",
+	},
+	testMultibaseEntry {
+		Str:     "yes mani !",
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "z7paNL19xttacUY",
+	},
+	testMultibaseEntry {
+		Str:     "yes mani !",
+		AlphIdx: AlphabetFlickr,
+		Enc:     "Z7Pznk19XTTzBtx",
+	},
+	testMultibaseEntry {
+		Str:     "\x00yes mani !",
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "z17paNL19xttacUY",
+	},
+	testMultibaseEntry {
+		Str:     "\x00\x00yes mani !",
+		AlphIdx: AlphabetFlickr,
+		Enc:     "Z117Pznk19XTTzBtx",
+	},
+}
+
+//
+// Functions
+//
+
+// Test multibase encoding/decoding
+func TestMultibase(t *testing.T) {
+	for _, currTest := range testVectMultibase {
+		// Encode
+		enc, err := MultibaseEncode(currTest.AlphIdx, []byte(currTest.Str))
+		if err != nil {
+			t.Errorf("Multibase encoding (%q) returned error: %s", currTest.Str, err.Error())
+		} else if enc != currTest.Enc {
+			t.Errorf("Multibase encoding was incorrect: expected %s, got: %s", currTest.Enc, enc)
+		}
+
+		// Decode
+		alphIdx, dec, err := MultibaseDecode(currTest.Enc)
+		if err != nil {
+			t.Errorf("Multibase decoding (%s) returned error: %s", currTest.Enc, err.Error())
+			continue
+		}
+		if alphIdx != currTest.AlphIdx || bytes.Compare(dec, []byte(currTest.Str)) != 0 {
+			t.Errorf("Multibase decoding (%s) was incorrect: got %d %q", currTest.Enc, alphIdx, dec)
+		}
+	}
+}
+
+// Test multibase invalid inputs
+func TestMultibaseInvalid(t *testing.T) {
+	// Alphabet without multibase prefix
+	if _, err := MultibaseEncode(AlphabetRipple, []byte("test")); err != ErrMultibaseAlphabet {
+		t.Errorf("Multibase encoding with Ripple alphabet returned wrong error")
+	}
+	if _, err := MultibaseEncode(10, []byte("test")); err != ErrMultibaseAlphabet {
+		t.Errorf("Multibase encoding with invalid alphabet returned wrong error")
+	}
+
+	// Unknown or missing prefix
+	testVectPrefix := []struct {
+		Enc    string
+		Prefix rune
+	} {
+		{ "", 0 },
+		{ "f796573206d616e692021", 'f' },
+		{ "7paNL19xttacUY", '7' },
+		{ "\u00e87paNL19xttacUY", '\u00e8' },
+		{ "\xffabc", 0xff },
+	}
+	for _, currTest := range testVectPrefix {
+		_, _, err := MultibaseDecode(currTest.Enc)
+		if !errors.Is(err, ErrMultibasePrefix) {
+			t.Errorf("Multibase decoding (%s) returned wrong error: %v", currTest.Enc, err)
+			continue
+		}
+		if prefixErr, ok := err.(MultibasePrefixError); !ok || prefixErr.Prefix != currTest.Prefix {
+			t.Errorf("Multibase decoding (%s) returned wrong prefix: %v", currTest.Enc, err)
+		}
+	}
+
+	// Invalid character, offset shall include the prefix
+	_, _, err := MultibaseDecode("z7paNL0")
+	if charErr, ok := err.(InvalidCharacterError); !ok || charErr != (InvalidCharacterError { Offset: 6, Char: '0', Alphabet: "Bitcoin" }) {
+		t.Errorf("Multibase decoding with invalid character returned wrong error: %v", err)
+	}
+}
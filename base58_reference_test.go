@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the reference implementation of base58 encoding/decoding based on big.Int.
+// It is slow, but simple enough to be used for checking the optimized implementation.
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"math/big"
+	"strings"
+)
+
+//
+// Variables
+//
+var (
+	// Zero as big.Int
+	bigZero = big.NewInt(0)
+	// Base58 radix as big.Int
+	bigRadix = big.NewInt(58)
+)
+
+//
+// Not-exported functions
+//
+
+// Encode the specified bytes to Base58 format using big.Int (reference implementation).
+func encodeReference(input []byte, alphabet string) string {
+	// Create slice for encoded output
+	enc := make([]byte, 0, MaxEncodedLen(len(input)))
+
+	// Convert bytes to big integer
+	encVal := byteSliceToBigInt(input)
+
+	// Get encoding bytes from integer
+	mod := new(big.Int)
+	for encVal.Cmp(bigZero) > 0 {
+		encVal.DivMod(encVal, bigRadix, mod)
+		enc = append(enc, alphabet[mod.Int64()])
+	}
+
+	// Pad encoding depending on the number of initial zeros
+	enc = padEncoding(enc, input, alphabet)
+
+	// Reverse bytes slice
+	reverseByteSlice(enc)
+
+	// Convert to string
+	return string(enc)
+}
+
+// Decode the specified string in Base58 format to bytes using big.Int (reference implementation).
+func decodeReference(input string, alphabet string) ([]byte, error) {
+	decVal := big.NewInt(0)
+
+	// Convert the string back to big integer
+	mult := big.NewInt(1)
+	tmp  := new(big.Int)
+
+	for i := len(input) - 1; i >= 0; i-- {
+		// Find character in the alphabet
+		chrIdx := strings.IndexByte(alphabet, input[i])
+		// Format error if not found
+		if chrIdx == -1 {
+			return nil, ErrInvalidFormat
+		}
+		// Update value: val += mult * chrIdx
+		tmp.SetInt64(int64(chrIdx))
+		tmp.Mul(mult, tmp)
+		decVal.Add(decVal, tmp)
+		// Increase multiplier: mult = mult * 58
+		mult.Mul(mult, bigRadix)
+	}
+
+	// Pad decoding depending on the number of the first alphabet letter
+	dec := padDecoding(decVal.Bytes(), input, alphabet)
+
+	return dec, nil
+}
+
+// Convert the specified byte slice to big.Int
+func byteSliceToBigInt(slice []byte) (*big.Int) {
+	bigVal := new(big.Int)
+	bigVal.SetBytes(slice)
+
+	return bigVal
+}
+
+// Pad decoding by adding zeros as many times as the number of leading first alphabet characters in the original string.
+func padDecoding(dec []byte, input string, alphabet string) []byte {
+	// Compute the number of zeros to be added
+	zerosCnt := countLeadingFirstAlphChar(input, alphabet[0])
+
+	// Pad bytes with zeros
+	decPadded := make([]byte, len(dec) + zerosCnt)
+	copy(decPadded[zerosCnt:], dec)
+
+	return decPadded
+}
+
+// Pad encoding by adding the first alphabet letter as many times as the number of leading zeros in the original bytes.
+func padEncoding(enc []byte, input []byte, alphabet string) []byte {
+	for _, b := range(input) {
+		if b != 0 {
+			break
+		}
+		enc = append(enc, alphabet[0])
+	}
+
+	return enc
+}
+
+// Reverse the specified byte slice
+func reverseByteSlice(slice []byte) {
+	for i, j := 0, len(slice) - 1; i < j; i, j = i + 1, j - 1 {
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
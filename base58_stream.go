@@ -0,0 +1,215 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the streaming encoder/decoder for base58 package.
+// Since base58 is not naturally streamable, streams are encoded in fixed-size blocks:
+// every 8 bytes are encoded independently to exactly 11 characters, and the last
+// partial block of n bytes (1 <= n <= 7) is encoded to 2, 3, 5, 6, 7, 9 or 10 characters.
+// This is the same framing used by Monero, so the result is not compatible with Encode.
+//
+
+package base58
+
+//
+// Imports
+//
+import (
+	"io"
+)
+
+//
+// Constants
+//
+const (
+	// Number of blocks processed at a time by the streaming encoder/decoder
+	streamBlocksNum = 64
+)
+
+//
+// Types
+//
+
+// Streaming encoder structure
+type encoder struct {
+	alph *Alphabet
+	w    io.Writer
+	err  error
+	buf  [blockSize]byte
+	nbuf int
+	out  [streamBlocksNum * encodedBlockSize]byte
+}
+
+// Streaming decoder structure
+type decoder struct {
+	alph    *Alphabet
+	r       io.Reader
+	err     error
+	readErr error
+	buf     [streamBlocksNum * encodedBlockSize]byte
+	nbuf    int
+	offset  int
+	out     []byte
+	outBuf  [streamBlocksNum * blockSize]byte
+}
+
+//
+// Exported functions
+//
+
+// Create a new streaming encoder using the alphabet of the specified base58 object.
+// Data written to the returned writer is block-encoded and written to w.
+// The caller shall Close the returned writer for flushing the last partial block.
+// If the alphabet is not valid, writing returns ErrInvalidAlphabet.
+func NewEncoder(obj *Base58Obj, w io.Writer) io.WriteCloser {
+	alph, err := obj.getAlphabet()
+
+	return &encoder {
+		alph: alph,
+		w:    w,
+		err:  err,
+	}
+}
+
+// Create a new streaming decoder using the alphabet of the specified base58 object.
+// Data read from the returned reader is block-decoded from r. New line characters in r are ignored.
+// If the alphabet is not valid, reading returns ErrInvalidAlphabet.
+func NewDecoder(obj *Base58Obj, r io.Reader) io.Reader {
+	alph, err := obj.getAlphabet()
+
+	return &decoder {
+		alph: alph,
+		r:    r,
+		err:  err,
+	}
+}
+
+// Write the specified bytes to the encoder.
+func (enc *encoder) Write(p []byte) (int, error) {
+	if enc.err != nil {
+		return 0, enc.err
+	}
+
+	n := 0
+
+	// Complete the buffered partial block first
+	if enc.nbuf > 0 {
+		k := copy(enc.buf[enc.nbuf:], p)
+		enc.nbuf += k
+		n += k
+		p = p[k:]
+		if enc.nbuf < blockSize {
+			return n, nil
+		}
+
+		out := appendEncodeBlock(enc.out[:0], enc.buf[:], enc.alph)
+		if _, enc.err = enc.w.Write(out); enc.err != nil {
+			return n, enc.err
+		}
+		enc.nbuf = 0
+	}
+
+	// Encode full blocks, up to streamBlocksNum at a time
+	for len(p) >= blockSize {
+		k := len(p) - len(p) % blockSize
+		if k > streamBlocksNum * blockSize {
+			k = streamBlocksNum * blockSize
+		}
+
+		out := appendEncodeBlocks(enc.out[:0], p[:k], enc.alph)
+		if _, enc.err = enc.w.Write(out); enc.err != nil {
+			return n, enc.err
+		}
+		n += k
+		p = p[k:]
+	}
+
+	// Buffer the remaining bytes
+	enc.nbuf = copy(enc.buf[:], p)
+	n += enc.nbuf
+
+	return n, nil
+}
+
+// Flush the last partial block, if any, and close the encoder.
+// It does not close the underlying writer.
+func (enc *encoder) Close() error {
+	if enc.err == nil && enc.nbuf > 0 {
+		out := appendEncodeBlock(enc.out[:0], enc.buf[:enc.nbuf], enc.alph)
+		_, enc.err = enc.w.Write(out)
+		enc.nbuf = 0
+	}
+	return enc.err
+}
+
+// Read decoded bytes from the decoder.
+// Invalid characters are reported with their offset in the stream, new lines excluded.
+func (dec *decoder) Read(p []byte) (int, error) {
+	for {
+		// Return pending decoded bytes first
+		if len(dec.out) > 0 {
+			n := copy(p, dec.out)
+			dec.out = dec.out[n:]
+			return n, nil
+		}
+		if dec.err != nil {
+			return 0, dec.err
+		}
+
+		// Read characters, removing new lines
+		if dec.readErr == nil {
+			n, err := dec.r.Read(dec.buf[dec.nbuf:])
+			dec.nbuf += removeNewLines(dec.buf[dec.nbuf:dec.nbuf + n])
+			dec.readErr = err
+		}
+
+		// Decode full blocks, and the last partial one at the end of the stream
+		decLen := dec.nbuf - dec.nbuf % encodedBlockSize
+		if dec.readErr == io.EOF {
+			decLen = dec.nbuf
+		}
+		n, err := decodeBlocks(dec.outBuf[:], string(dec.buf[:decLen]), dec.offset, dec.alph)
+		dec.out = dec.outBuf[:n]
+		dec.nbuf = copy(dec.buf[:], dec.buf[decLen:dec.nbuf])
+		dec.offset += decLen
+
+		if err != nil {
+			dec.err = err
+		} else if dec.readErr != nil {
+			dec.err = dec.readErr
+		}
+	}
+}
+
+//
+// Not-exported functions
+//
+
+// Remove new line characters from the specified slice in-place, returning the new length
+func removeNewLines(slice []byte) int {
+	n := 0
+	for _, b := range slice {
+		if b != '\r' && b != '\n' {
+			slice[n] = b
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package base58
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//
+// Types
+//
+
+// Stream test entry structure
+type testStreamEntry struct {
+	Hex string
+	Enc string
+}
+
+// Writer always returning error
+type errWriter struct {}
+
+//
+// Variables
+//
+
+// Test vector for stream encoding (Bitcoin alphabet)
+var testVectStream = []testStreamEntry {
+	testStreamEntry {
+		Hex: "",
+		Enc: "",
+	},
+	testStreamEntry {
+		Hex: "00",
+		Enc: "11",
+	},
+	testStreamEntry {
+		Hex: "ff",
+		Enc: "5Q",
+	},
+	testStreamEntry {
+		Hex: "0000000000000000",
+		Enc: "11111111111",
+	},
+	testStreamEntry {
+		Hex: "ffffffffffffffff",
+		Enc: "jpXCZedGfVQ",
+	},
+	testStreamEntry {
+		Hex: "00000000000000000000",
+		Enc: "11111111111111",
+	},
+	testStreamEntry {
+		Hex: "06156013762879f7ffffffffff",
+		Enc: "22222222222VtB5VXc",
+	},
+}
+
+// Tests for stream decoding errors
+var testVectStreamInvalid = map[string]error {
+	"1":                   ErrInvalidBlockLen,
+	"1111":                ErrInvalidBlockLen,
+	"111111111111111":     ErrInvalidBlockLen,
+	"1111111111111111111": ErrInvalidBlockLen,
+	"zzzzzzzzzzz":         ErrInvalidBlock,
+	"jpXCZedGfVR":         ErrInvalidBlock,
+	"5R":                  ErrInvalidBlock,
+	"11111111111zz":       ErrInvalidBlock,
+}
+
+//
+// Functions
+//
+
+// Write returning error
+func (w *errWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+// Encode the specified bytes with the streaming encoder, writing one byte at a time if requested
+func streamEncode(t *testing.T, obj *Base58Obj, raw []byte, oneByte bool) string {
+	var buf bytes.Buffer
+	enc := NewEncoder(obj, &buf)
+
+	if oneByte {
+		for i := range raw {
+			if _, err := enc.Write(raw[i:i + 1]); err != nil {
+				t.Fatalf("Stream encoding returned error: %s", err.Error())
+			}
+		}
+	} else if _, err := enc.Write(raw); err != nil {
+		t.Fatalf("Stream encoding returned error: %s", err.Error())
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Closing stream encoder returned error: %s", err.Error())
+	}
+
+	return buf.String()
+}
+
+// Test stream encoding and decoding
+func TestStream(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for _, currTest := range testVectStream {
+		raw, _ := hex.DecodeString(currTest.Hex)
+
+		for _, oneByte := range []bool { false, true } {
+			if enc := streamEncode(t, base58Btc, raw, oneByte); enc != currTest.Enc {
+				t.Errorf("Stream encoding was incorrect: expected %s, got: %s", currTest.Enc, enc)
+			}
+		}
+
+		dec, err := ioutil.ReadAll(NewDecoder(base58Btc, iotest.OneByteReader(strings.NewReader(currTest.Enc))))
+		if err != nil {
+			t.Errorf("Stream decoding (%s) returned error: %s", currTest.Enc, err.Error())
+		}
+		if bytes.Compare(dec, raw) != 0 {
+			t.Errorf("Stream decoding was incorrect: expected %x, got: %x", raw, dec)
+		}
+	}
+}
+
+// Test stream encoding and decoding of random data
+func TestStreamRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	for alphIdx := range alphabetMap {
+		base58Obj := New(alphIdx)
+
+		for i := 0; i < 100; i++ {
+			raw := make([]byte, rnd.Intn(4096))
+			rnd.Read(raw)
+
+			enc := streamEncode(t, base58Obj, raw, i % 2 == 0)
+			if len(enc) != len(raw) / blockSize * encodedBlockSize + encodedBlockSizes[len(raw) % blockSize] {
+				t.Errorf("Stream encoding length was incorrect for %d bytes: %d", len(raw), len(enc))
+			}
+
+			// Add new lines, they shall be ignored
+			encLines := ""
+			for len(enc) > 76 {
+				encLines, enc = encLines + enc[:76] + "\r\n", enc[76:]
+			}
+			encLines += enc
+
+			dec, err := ioutil.ReadAll(NewDecoder(base58Obj, iotest.HalfReader(strings.NewReader(encLines))))
+			if err != nil {
+				t.Errorf("Stream decoding returned error: %s", err.Error())
+			}
+			if bytes.Compare(dec, raw) != 0 {
+				t.Errorf("Stream decoding of %d bytes was incorrect", len(raw))
+			}
+		}
+	}
+}
+
+// Test stream decoding errors
+func TestStreamInvalid(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for enc, expErr := range testVectStreamInvalid {
+		_, err := ioutil.ReadAll(NewDecoder(base58Btc, strings.NewReader(enc)))
+		if err != expErr {
+			t.Errorf("Stream decoding (%s) returned wrong error: expected %v, got: %v", enc, expErr, err)
+		}
+	}
+
+	// Invalid character, offset shall not count new lines
+	_, err := ioutil.ReadAll(NewDecoder(base58Btc, strings.NewReader("11111111111\n11111111110")))
+	expErr := InvalidCharacterError { Offset: 21, Char: '0', Alphabet: "Bitcoin" }
+	var charErr InvalidCharacterError
+	if !errors.As(err, &charErr) || charErr != expErr {
+		t.Errorf("Stream decoding with invalid character returned wrong error: expected %v, got: %v", expErr, err)
+	}
+
+	// Read errors shall be returned
+	_, err = ioutil.ReadAll(NewDecoder(base58Btc, iotest.TimeoutReader(strings.NewReader("11111111111"))))
+	if err != iotest.ErrTimeout {
+		t.Errorf("Stream decoding with read error returned wrong error: %v", err)
+	}
+
+	// Invalid alphabet
+	if _, err = NewEncoder(New(3), ioutil.Discard).Write([]byte("test")); err != ErrInvalidAlphabet {
+		t.Errorf("Stream encoding with invalid alphabet returned wrong error")
+	}
+	if _, err = NewDecoder(New(3), strings.NewReader("test")).Read(make([]byte, 8)); err != ErrInvalidAlphabet {
+		t.Errorf("Stream decoding with invalid alphabet returned wrong error")
+	}
+}
+
+// Test that write errors are returned by the stream encoder
+func TestStreamWriteError(t *testing.T) {
+	enc := NewEncoder(New(AlphabetBitcoin), &errWriter {})
+	if _, err := enc.Write(make([]byte, 16)); err != io.ErrShortWrite {
+		t.Errorf("Stream encoding with write error returned wrong error")
+	}
+	if err := enc.Close(); err != io.ErrShortWrite {
+		t.Errorf("Closing stream encoder with write error returned wrong error")
+	}
+}
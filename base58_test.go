@@ -26,6 +26,9 @@ package base58
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -40,6 +43,27 @@ type testVectEntry struct {
 	CheckEnc string
 }
 
+// Versioned test entry structure
+type testVersionEntry struct {
+	Version  string
+	Payload  string
+	CheckEnc string
+}
+
+// Checksum test entry structure
+type testChecksumEntry struct {
+	Chksum   Checksum
+	Hex      string
+	CheckEnc string
+}
+
+// Invalid character test entry structure
+type testInvalidCharEntry struct {
+	AlphIdx int
+	Enc     string
+	Err     InvalidCharacterError
+}
+
 //
 // Variables
 //
@@ -251,6 +275,62 @@ var testVectFlickr = []testVectEntry {
 	},
 }
 
+// Test vector for versioned encoding (Bitcoin alphabet)
+var testVectVersion = []testVersionEntry {
+	// Bitcoin P2PKH address
+	testVersionEntry {
+		Version:  "00",
+		Payload:  "62e907b15cbf27d5425399ebf6f0fb50ebb88f18",
+		CheckEnc: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+	},
+	// Zcash transparent P2PKH address
+	testVersionEntry {
+		Version:  "1cb8",
+		Payload:  "62e907b15cbf27d5425399ebf6f0fb50ebb88f18",
+		CheckEnc: "t1StbPM4X3j4FGM57HpGnb9BMbS7C1nFW1r",
+	},
+	// BIP32 extended public key
+	testVersionEntry {
+		Version:  "0488b21e",
+		Payload:  "000000000000000000873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d5080339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+		CheckEnc: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+	},
+}
+
+// Test vector for checksum algorithms (Bitcoin alphabet)
+var testVectChecksum = []testChecksumEntry {
+	// Avalanche empty ID
+	testChecksumEntry {
+		Chksum:   ChecksumSha256Tail,
+		Hex:      "0000000000000000000000000000000000000000000000000000000000000000",
+		CheckEnc: "11111111111111111111111111111111LpoYY",
+	},
+	// Avalanche AVAX asset ID
+	testChecksumEntry {
+		Chksum:   ChecksumSha256Tail,
+		Hex:      "21e67317cbc4be2aeb00677ad6462778a8f52274b9d605df2591b23027a87dff",
+		CheckEnc: "FvwEAhmxKfeiG8SnEvq42hc6whRyY3EFYAvebMqDNDGCgxN5Z",
+	},
+	// Substrate generic address of Alice
+	testChecksumEntry {
+		Chksum:   ChecksumBlake2b512,
+		Hex:      "2ad43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		CheckEnc: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+	},
+	// Polkadot address of Alice
+	testChecksumEntry {
+		Chksum:   ChecksumBlake2b512,
+		Hex:      "00d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		CheckEnc: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+	},
+	// Bitcoin P2PKH address, explicit default checksum
+	testChecksumEntry {
+		Chksum:   ChecksumDoubleSha256,
+		Hex:      "0062e907b15cbf27d5425399ebf6f0fb50ebb88f18",
+		CheckEnc: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+	},
+}
+
 // Tests for base58 encoded strings with invalid checksum
 var testVectChksumInvalid = []string {
 	"237LSrY9NUUar",
@@ -258,6 +338,15 @@ var testVectChksumInvalid = []string {
 	"2W1Yd5Zu6WGyKVtHGMrJ",
 }
 
+// Tests for base58 encoded strings too short for containing the checksum
+var testVectShortInput = []string {
+	"",
+	"1",
+	"2g",
+	"111",
+	"a3gV",
+}
+
 // Tests for base58 encoded strings with invalid encoding
 var testVectEncodingInvalid = []string {
 	"237LSrYONUUar",
@@ -265,10 +354,77 @@ var testVectEncodingInvalid = []string {
 	"2WlYd5Zu6WGyKVtHGMrJ",
 }
 
+// Tests for invalid character errors
+var testVectInvalidChar = []testInvalidCharEntry {
+	testInvalidCharEntry {
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "237LSrYONUUar",
+		Err:     InvalidCharacterError { Offset: 7, Char: 'O', Alphabet: "Bitcoin" },
+	},
+	testInvalidCharEntry {
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "0GwDDDeduj1jpykc27",
+		Err:     InvalidCharacterError { Offset: 0, Char: '0', Alphabet: "Bitcoin" },
+	},
+	testInvalidCharEntry {
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "111Il",
+		Err:     InvalidCharacterError { Offset: 3, Char: 'I', Alphabet: "Bitcoin" },
+	},
+	testInvalidCharEntry {
+		AlphIdx: AlphabetRipple,
+		Enc:     "rrpshl",
+		Err:     InvalidCharacterError { Offset: 5, Char: 'l', Alphabet: "Ripple" },
+	},
+	testInvalidCharEntry {
+		AlphIdx: AlphabetFlickr,
+		Enc:     "abc\u00e9",
+		Err:     InvalidCharacterError { Offset: 3, Char: '\u00e9', Alphabet: "Flickr" },
+	},
+	testInvalidCharEntry {
+		AlphIdx: AlphabetBitcoin,
+		Enc:     "1\xff",
+		Err:     InvalidCharacterError { Offset: 1, Char: 0xff, Alphabet: "Bitcoin" },
+	},
+}
+
+// Number of random inputs for differential tests
+const testDiffRandNum = 1000
+
+// Names of built-in alphabets, for benchmarks
+var testAlphabetNames = map[int]string {
+	AlphabetBitcoin: "Bitcoin",
+	AlphabetRipple:  "Ripple",
+	AlphabetFlickr:  "Flickr",
+}
+
 //
 // Functions
 //
 
+// Generate random bytes with a random number of leading zeros
+func randomBytes(rnd *rand.Rand) []byte {
+	slice := make([]byte, rnd.Intn(8) + rnd.Intn(128))
+	rnd.Read(slice[rnd.Intn(len(slice) + 1):])
+
+	return slice
+}
+
+// Generate a random string with the specified alphabet and a random number of leading first alphabet characters
+func randomString(rnd *rand.Rand, alphabet string) string {
+	str := make([]byte, rnd.Intn(8) + rnd.Intn(160))
+	zerosCnt := rnd.Intn(len(str) + 1)
+	for i := range str {
+		if i < zerosCnt {
+			str[i] = alphabet[0]
+		} else {
+			str[i] = alphabet[rnd.Intn(len(alphabet))]
+		}
+	}
+
+	return string(str)
+}
+
 // Test encoding for a generic base58 object
 func GenericTestEncoder(t *testing.T, testEntries []testVectEntry, obj *Base58Obj) {
 	for _, currTest := range testEntries {
@@ -351,17 +507,79 @@ func TestInvalidEncoding(t *testing.T) {
 	for _, currTest := range testVectEncodingInvalid {
 		// Decode
 		_, err := base58Btc.Decode(currTest)
-		if err != ErrInvalidFormat {
+		if !errors.Is(err, ErrInvalidFormat) {
 			t.Errorf("Decoding (%s) with invalid encoding returned wrong error", currTest)
 		}
 		// CheckDecode
 		_, err = base58Btc.CheckDecode(currTest)
-		if err != ErrInvalidFormat {
+		if !errors.Is(err, ErrInvalidFormat) {
 			t.Errorf("Checksum decoding (%s) with invalid encoding returned wrong error", currTest)
 		}
 	}
 }
 
+// Test invalid character errors
+func TestInvalidCharacterError(t *testing.T) {
+	for _, currTest := range testVectInvalidChar {
+		base58Obj := New(currTest.AlphIdx)
+
+		// Decode
+		_, err := base58Obj.Decode(currTest.Enc)
+		var charErr InvalidCharacterError
+		if !errors.As(err, &charErr) || charErr != currTest.Err {
+			t.Errorf("Decoding (%s) returned wrong error: expected %v, got: %v", currTest.Enc, currTest.Err, err)
+		}
+		if !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Decoding (%s) returned error not matching ErrInvalidFormat", currTest.Enc)
+		}
+
+		// CheckDecode
+		_, err = base58Obj.CheckDecode(currTest.Enc)
+		if !errors.As(err, &charErr) || charErr != currTest.Err {
+			t.Errorf("Checksum decoding (%s) returned wrong error: expected %v, got: %v", currTest.Enc, currTest.Err, err)
+		}
+	}
+}
+
+// Test object creation with alphabet validation
+func TestNewE(t *testing.T) {
+	// Valid alphabets
+	for alphIdx := range alphabetMap {
+		base58Obj, err := NewE(alphIdx)
+		if err != nil {
+			t.Errorf("Creating object with valid alphabet returned error: %s", err.Error())
+		}
+		if base58Obj == nil || base58Obj.AlphIdx != alphIdx {
+			t.Errorf("Creating object with valid alphabet returned wrong result")
+		}
+
+		// EncodeE/CheckEncodeE shall return the same result of Encode/CheckEncode
+		enc, err := base58Obj.EncodeE([]byte("test"))
+		if err != nil || enc != base58Obj.Encode([]byte("test")) {
+			t.Errorf("Encoding with valid alphabet returned wrong result")
+		}
+		enc, err = base58Obj.CheckEncodeE([]byte("test"))
+		if err != nil || enc != base58Obj.CheckEncode([]byte("test")) {
+			t.Errorf("Checksum encoding with valid alphabet returned wrong result")
+		}
+		enc, err = base58Obj.CheckEncodeVersionE([]byte { 0x00 }, []byte("test"))
+		if err != nil || enc != base58Obj.CheckEncodeVersion([]byte { 0x00 }, []byte("test")) {
+			t.Errorf("Versioned encoding with valid alphabet returned wrong result")
+		}
+	}
+
+	// Invalid alphabets
+	for _, alphIdx := range []int { -1, 3, 100 } {
+		base58Obj, err := NewE(alphIdx)
+		if base58Obj != nil {
+			t.Errorf("Creating object with invalid alphabet returned not-nil result")
+		}
+		if err != ErrInvalidAlphabet {
+			t.Errorf("Creating object with invalid alphabet returned wrong error")
+		}
+	}
+}
+
 // Test invalid alphabet
 func TestInvalidAlphabet(t *testing.T) {
 	// Create with invalid alphabet
@@ -379,6 +597,39 @@ func TestInvalidAlphabet(t *testing.T) {
 		t.Errorf("Checksum encoding with invalid alphabet returned not-empty result")
 	}
 
+	// EncodeE
+	enc, err := base58Obj.EncodeE([]byte("test"))
+	if enc != "" {
+		t.Errorf("Encoding with invalid alphabet returned not-empty result")
+	}
+	if err != ErrInvalidAlphabet {
+		t.Errorf("Encoding with invalid alphabet returned wrong error")
+	}
+
+	// CheckEncodeE
+	enc, err = base58Obj.CheckEncodeE([]byte("test"))
+	if enc != "" {
+		t.Errorf("Checksum encoding with invalid alphabet returned not-empty result")
+	}
+	if err != ErrInvalidAlphabet {
+		t.Errorf("Checksum encoding with invalid alphabet returned wrong error")
+	}
+
+	// CheckEncodeVersion
+	enc = base58Obj.CheckEncodeVersion([]byte { 0x00 }, []byte("test"))
+	if enc != "" {
+		t.Errorf("Versioned encoding with invalid alphabet returned not-empty result")
+	}
+
+	// CheckEncodeVersionE
+	enc, err = base58Obj.CheckEncodeVersionE([]byte { 0x00 }, []byte("test"))
+	if enc != "" {
+		t.Errorf("Versioned encoding with invalid alphabet returned not-empty result")
+	}
+	if err != ErrInvalidAlphabet {
+		t.Errorf("Versioned encoding with invalid alphabet returned wrong error")
+	}
+
 	// Decode
 	dec, err := base58Obj.Decode("test")
 	if dec != nil {
@@ -397,3 +648,483 @@ func TestInvalidAlphabet(t *testing.T) {
 		t.Errorf("Checksum decoding with invalid alphabet returned wrong error")
 	}
 }
+
+// Test Append/Into APIs
+func TestAppendAndInto(t *testing.T) {
+	for alphIdx, testEntries := range map[int][]testVectEntry { AlphabetBitcoin: testVectBtc, AlphabetRipple: testVectXrp, AlphabetFlickr: testVectFlickr } {
+		base58Obj := New(alphIdx)
+
+		for _, currTest := range testEntries {
+			raw, _ := hex.DecodeString(currTest.Hex)
+
+			// AppendEncode, with garbage in the spare capacity
+			prefix := []byte("prefix")
+			dst := append(make([]byte, 0, 1024), prefix...)
+			copy(dst[:cap(dst)][len(prefix):], bytes.Repeat([]byte { 0xff }, 1024 - len(prefix)))
+			enc := base58Obj.AppendEncode(dst, raw)
+			if string(enc) != string(prefix) + currTest.Enc {
+				t.Errorf("Append encoding was incorrect: expected %s, got: %s", string(prefix) + currTest.Enc, enc)
+			}
+
+			// AppendDecode, with garbage in the spare capacity
+			dec, err := base58Obj.AppendDecode(dst, currTest.Enc)
+			if err != nil {
+				t.Errorf("Append decoding (%s) returned error: %s", currTest.Hex, err.Error())
+			}
+			if bytes.Compare(dec, append(prefix, raw...)) != 0 {
+				t.Errorf("Append decoding was incorrect: expected %v, got: %v", append(prefix, raw...), dec)
+			}
+
+			// DecodeInto, with garbage in the buffer
+			buf := bytes.Repeat([]byte { 0xff }, len(raw))
+			n, err := base58Obj.DecodeInto(buf, currTest.Enc)
+			if err != nil {
+				t.Errorf("Decoding into buffer (%s) returned error: %s", currTest.Hex, err.Error())
+			}
+			if bytes.Compare(buf[:n], raw) != 0 {
+				t.Errorf("Decoding into buffer was incorrect: expected %v, got: %v", raw, buf[:n])
+			}
+
+			// DecodeInto with too small buffer
+			if len(raw) > 0 {
+				_, err = base58Obj.DecodeInto(buf[:len(raw) - 1], currTest.Enc)
+				if err != ErrBufferTooSmall {
+					t.Errorf("Decoding into small buffer (%s) returned wrong error", currTest.Hex)
+				}
+			}
+		}
+	}
+
+	// Invalid format shall leave dst unchanged
+	dst := []byte("prefix")
+	dec, err := New(AlphabetBitcoin).AppendDecode(dst, "0OIl")
+	if !errors.Is(err, ErrInvalidFormat) || string(dec) != "prefix" {
+		t.Errorf("Append decoding with invalid format returned wrong result")
+	}
+}
+
+// Test that Append/Into APIs do not allocate
+func TestAppendAndIntoAllocs(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+	raw, _ := hex.DecodeString("00eb15231dfceb60925886b67d065299925915aeb172c06647")
+	enc := "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"
+	dst := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		base58Btc.AppendEncode(dst, raw)
+	})
+	if allocs != 0 {
+		t.Errorf("Append encoding performed %v allocations", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		base58Btc.AppendDecode(dst, enc)
+	})
+	if allocs != 0 {
+		t.Errorf("Append decoding performed %v allocations", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		base58Btc.DecodeInto(dst[:cap(dst)], enc)
+	})
+	if allocs != 0 {
+		t.Errorf("Decoding into buffer performed %v allocations", allocs)
+	}
+}
+
+// Test encoding and decoding of inputs not fitting the stack limbs
+func TestLargeInput(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	raw := make([]byte, 4096)
+	rnd.Read(raw[16:])
+
+	for _, alph := range alphabetMap {
+		enc := encode(raw, alph)
+		if encRef := encodeReference(raw, alph.String()); enc != encRef {
+			t.Errorf("Encoding of large input differs from reference")
+		}
+		dec, err := decode(enc, alph)
+		if err != nil || bytes.Compare(dec, raw) != 0 {
+			t.Errorf("Decoding of large input was incorrect")
+		}
+	}
+}
+
+// Test encoding and decoding against the big.Int reference implementation
+func TestDifferential(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	for _, alph := range alphabetMap {
+		alphabet := alph.String()
+
+		for i := 0; i < testDiffRandNum; i++ {
+			// Encode
+			raw := randomBytes(rnd)
+			enc, encRef := encode(raw, alph), encodeReference(raw, alphabet)
+			if enc != encRef {
+				t.Errorf("Encoding of %x differs from reference: expected %s, got: %s", raw, encRef, enc)
+			}
+
+			// Decode
+			str := randomString(rnd, alphabet)
+			dec, err := decode(str, alph)
+			if err != nil {
+				t.Errorf("Decoding (%s) returned error: %s", str, err.Error())
+			}
+			decRef, _ := decodeReference(str, alphabet)
+			if bytes.Compare(dec, decRef) != 0 {
+				t.Errorf("Decoding of %s differs from reference: expected %x, got: %x", str, decRef, dec)
+			}
+		}
+	}
+}
+
+// Test decode tables against a linear search in the alphabet
+func TestDecodeTable(t *testing.T) {
+	for alphIdx, alph := range alphabetMap {
+		alphabet := alph.String()
+
+		for c := 0; c < 256; c++ {
+			idx, expIdx := alph.Index(byte(c)), strings.IndexByte(alphabet, byte(c))
+			if idx != expIdx {
+				t.Errorf("Index of character %#x in alphabet %d was incorrect: expected %d, got: %d", c, alphIdx, expIdx, idx)
+			}
+		}
+	}
+}
+
+// Benchmark encoding of a 32-byte key
+func BenchmarkEncode(b *testing.B) {
+	raw, _ := hex.DecodeString("3bf3a6b25b3a9af6c4c5e8a0b4b5d1c48f9fa2d21a0e7f08a7e4e0c96a1b2c3d")
+	for i := 0; i < b.N; i++ {
+		encode(raw, alphabetMap[AlphabetBitcoin])
+	}
+}
+
+// Benchmark encoding of a 32-byte key with the reference implementation
+func BenchmarkEncodeReference(b *testing.B) {
+	raw, _ := hex.DecodeString("3bf3a6b25b3a9af6c4c5e8a0b4b5d1c48f9fa2d21a0e7f08a7e4e0c96a1b2c3d")
+	for i := 0; i < b.N; i++ {
+		encodeReference(raw, alphabetMap[AlphabetBitcoin].String())
+	}
+}
+
+// Benchmark decoding of a 32-byte key
+func BenchmarkDecode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		decode("52AaQwzpBfJ3Ry5xBmrdoNYHnQnc6kYGM1hz1Ceh8WWt", alphabetMap[AlphabetBitcoin])
+	}
+}
+
+// Benchmark decoding of a 32-byte key with the reference implementation
+func BenchmarkDecodeReference(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		decodeReference("52AaQwzpBfJ3Ry5xBmrdoNYHnQnc6kYGM1hz1Ceh8WWt", alphabetMap[AlphabetBitcoin].String())
+	}
+}
+
+// Test custom alphabets
+func TestCustomAlphabet(t *testing.T) {
+	// Custom alphabet with the same characters of a built-in one shall behave in the same way
+	for alphIdx, testEntries := range map[int][]testVectEntry { AlphabetBitcoin: testVectBtc, AlphabetRipple: testVectXrp, AlphabetFlickr: testVectFlickr } {
+		alph, err := NewAlphabet(alphabetMap[alphIdx].String())
+		if err != nil {
+			t.Fatalf("Creating alphabet returned error: %s", err.Error())
+		}
+		GenericTestEncoder(t, testEntries, NewWithAlphabet(alph))
+		GenericTestDecoder(t, testEntries, NewWithAlphabet(alph))
+	}
+
+	// Custom alphabet shall take precedence over the alphabet index
+	alph, err := NewAlphabet("zyxwvutsrqponmkjihgfedcbaZYXWVUTSRQPNMLKJHGFEDCBA987654321")
+	if err != nil {
+		t.Fatalf("Creating alphabet returned error: %s", err.Error())
+	}
+	if alph.Name() != customAlphabetName {
+		t.Errorf("Custom alphabet name was incorrect: expected %s, got: %s", customAlphabetName, alph.Name())
+	}
+	base58Obj := &Base58Obj { AlphIdx: 3, Alph: alph }
+
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < testDiffRandNum; i++ {
+		raw := randomBytes(rnd)
+
+		enc := base58Obj.Encode(raw)
+		if encRef := encodeReference(raw, alph.String()); enc != encRef {
+			t.Errorf("Encoding with custom alphabet was incorrect: expected %s, got: %s", encRef, enc)
+		}
+		dec, err := base58Obj.Decode(enc)
+		if err != nil {
+			t.Errorf("Decoding with custom alphabet (%s) returned error: %s", enc, err.Error())
+		}
+		if bytes.Compare(dec, raw) != 0 {
+			t.Errorf("Decoding with custom alphabet was incorrect: expected %v, got: %v", raw, dec)
+		}
+	}
+}
+
+// Test invalid custom alphabets
+func TestInvalidCustomAlphabet(t *testing.T) {
+	testVect := map[string]error {
+		"":                                                            ErrAlphabetLength,
+		"123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxy":   ErrAlphabetLength,
+		"123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz0": ErrAlphabetLength,
+		"123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxy1":  ErrAlphabetDuplicatedChar,
+		"123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwx\xc3\xa9": ErrAlphabetNotAscii,
+	}
+
+	for chars, expErr := range testVect {
+		alph, err := NewAlphabet(chars)
+		if alph != nil {
+			t.Errorf("Creating invalid alphabet (%q) returned not-nil result", chars)
+		}
+		if err != expErr {
+			t.Errorf("Creating invalid alphabet (%q) returned wrong error", chars)
+		}
+	}
+
+	// Zero-value alphabet
+	base58Obj := NewWithAlphabet(&Alphabet {})
+	if enc, err := base58Obj.EncodeE([]byte("test")); enc != "" || err != ErrInvalidAlphabet {
+		t.Errorf("Encoding with zero-value alphabet returned wrong result")
+	}
+	if dec, err := base58Obj.Decode("test"); dec != nil || err != ErrInvalidAlphabet {
+		t.Errorf("Decoding with zero-value alphabet returned wrong result")
+	}
+}
+
+// Benchmark character lookup in the alphabet, using the decode table and a linear search
+func BenchmarkCharLookup(b *testing.B) {
+	for alphIdx, alph := range alphabetMap {
+		alphabet := alph.String()
+		enc := encode(bytes.Repeat([]byte { 0x5a, 0xc3, 0x17, 0xe8 }, 32), alph)
+
+		b.Run(testAlphabetNames[alphIdx] + "/Table", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for k := 0; k < len(enc); k++ {
+					if alph.Index(enc[k]) == -1 {
+						b.Fatal("Invalid character")
+					}
+				}
+			}
+		})
+		b.Run(testAlphabetNames[alphIdx] + "/IndexByte", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for k := 0; k < len(enc); k++ {
+					if strings.IndexByte(alphabet, enc[k]) == -1 {
+						b.Fatal("Invalid character")
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test input too short for containing the checksum
+func TestInputTooShort(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for _, currTest := range testVectShortInput {
+		// CheckDecode
+		dec, err := base58Btc.CheckDecode(currTest)
+		if dec != nil {
+			t.Errorf("Checksum decoding (%s) of short input returned not-nil result", currTest)
+		}
+		if err != ErrInputTooShort {
+			t.Errorf("Checksum decoding (%s) of short input returned wrong error", currTest)
+		}
+	}
+}
+
+// Test that no input causes a panic
+func TestMalformedInput(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Malformed input caused a panic: %v", r)
+		}
+	}()
+
+	// All strings up to 3 characters, mixing valid and invalid characters
+	chars := "1234zoOIl0 \x00\xff"
+	strs := []string { "" }
+	for i, prevStrs := 0, strs; i < 3; i++ {
+		var newStrs []string
+		for _, str := range prevStrs {
+			for k := 0; k < len(chars); k++ {
+				newStrs = append(newStrs, str + chars[k:k + 1])
+			}
+		}
+		strs, prevStrs = append(strs, newStrs...), newStrs
+	}
+
+	// Random strings of arbitrary bytes
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < testDiffRandNum; i++ {
+		str := make([]byte, rnd.Intn(64))
+		rnd.Read(str)
+		strs = append(strs, string(str))
+	}
+
+	for alphIdx := range alphabetMap {
+		base58Obj := New(alphIdx)
+
+		for _, str := range strs {
+			// Decode and CheckDecode shall not panic and return either result or error
+			dec, err := base58Obj.Decode(str)
+			if (dec == nil) == (err == nil) {
+				t.Errorf("Decoding (%q) returned inconsistent result and error", str)
+			}
+			dec, err = base58Obj.CheckDecode(str)
+			if (dec == nil) == (err == nil) {
+				t.Errorf("Checksum decoding (%q) returned inconsistent result and error", str)
+			}
+
+			// Encode and CheckEncode shall not panic on arbitrary bytes
+			base58Obj.Encode([]byte(str))
+			base58Obj.CheckEncode([]byte(str))
+		}
+	}
+}
+
+// Test encoded and decoded length bounds
+func TestLengthBounds(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+
+	// Worst cases: all 0xff bytes and all zeros
+	var inputs [][]byte
+	for n := 0; n < 300; n++ {
+		inputs = append(inputs, bytes.Repeat([]byte { 0xff }, n), make([]byte, n))
+	}
+	// Random inputs
+	for i := 0; i < testDiffRandNum; i++ {
+		inputs = append(inputs, randomBytes(rnd))
+	}
+
+	for _, alph := range alphabetMap {
+		for _, raw := range inputs {
+			enc := encode(raw, alph)
+			if len(enc) > MaxEncodedLen(len(raw)) {
+				t.Errorf("Encoded length of %x exceeds bound: %d > %d", raw, len(enc), MaxEncodedLen(len(raw)))
+			}
+			if len(enc) != EncodedLenExact(raw) {
+				t.Errorf("Exact encoded length of %x was incorrect: expected %d, got: %d", raw, len(enc), EncodedLenExact(raw))
+			}
+		}
+
+		for i := 0; i < testDiffRandNum; i++ {
+			str := randomString(rnd, alph.String())
+			dec, _ := decode(str, alph)
+			if len(dec) > MaxDecodedLen(len(str)) {
+				t.Errorf("Decoded length of %s exceeds bound: %d > %d", str, len(dec), MaxDecodedLen(len(str)))
+			}
+		}
+	}
+
+	if MaxEncodedLen(0) != 0 || MaxDecodedLen(0) != 0 || EncodedLenExact(nil) != 0 {
+		t.Errorf("Length of empty input was not zero")
+	}
+}
+
+// Test versioned encoding and decoding
+func TestVersion(t *testing.T) {
+	base58Btc := New(AlphabetBitcoin)
+
+	for _, currTest := range testVectVersion {
+		version, _ := hex.DecodeString(currTest.Version)
+		payload, _ := hex.DecodeString(currTest.Payload)
+
+		// CheckEncodeVersion
+		enc := base58Btc.CheckEncodeVersion(version, payload)
+		if enc != currTest.CheckEnc {
+			t.Errorf("Versioned encoding was incorrect: expected %s, got: %s", currTest.CheckEnc, enc)
+		}
+
+		// CheckDecodeVersion
+		decVer, decPayload, err := base58Btc.CheckDecodeVersion(currTest.CheckEnc, len(version))
+		if err != nil {
+			t.Errorf("Versioned decoding (%s) returned error: %s", currTest.CheckEnc, err.Error())
+		}
+		if bytes.Compare(decVer, version) != 0 || bytes.Compare(decPayload, payload) != 0 {
+			t.Errorf("Versioned decoding was incorrect: expected %x/%x, got: %x/%x", version, payload, decVer, decPayload)
+		}
+	}
+
+	// Version longer than decoded data
+	_, _, err := base58Btc.CheckDecodeVersion("C2dGTwc", 2)
+	if err != ErrInputTooShort {
+		t.Errorf("Versioned decoding of short input returned wrong error")
+	}
+	// Negative version length
+	_, _, err = base58Btc.CheckDecodeVersion("C2dGTwc", -1)
+	if err != ErrInvalidVersionLen {
+		t.Errorf("Versioned decoding with negative version length returned wrong error")
+	}
+	// Invalid checksum
+	_, _, err = base58Btc.CheckDecodeVersion("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", 1)
+	if err != ErrInvalidChecksum {
+		t.Errorf("Versioned decoding with invalid checksum returned wrong error")
+	}
+
+	// Appending to the version shall not modify the payload
+	decVer, decPayload, _ := base58Btc.CheckDecodeVersion("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", 1)
+	payload := append([]byte {}, decPayload...)
+	_ = append(decVer, 0xaa)
+	if bytes.Compare(decPayload, payload) != 0 {
+		t.Errorf("Appending to decoded version modified the payload: got %x", decPayload)
+	}
+}
+
+// Test checksum algorithms
+func TestChecksum(t *testing.T) {
+	for _, currTest := range testVectChecksum {
+		base58Obj := New(AlphabetBitcoin).WithChecksum(currTest.Chksum)
+		raw, _ := hex.DecodeString(currTest.Hex)
+
+		// CheckEncode
+		checkEnc := base58Obj.CheckEncode(raw)
+		if checkEnc != currTest.CheckEnc {
+			t.Errorf("Checksum encoding was incorrect: expected %s, got: %s", currTest.CheckEnc, checkEnc)
+		}
+
+		// CheckDecode
+		checkDec, err := base58Obj.CheckDecode(currTest.CheckEnc)
+		if err != nil {
+			t.Errorf("Checksum decoding (%s) returned error: %s", currTest.CheckEnc, err.Error())
+		}
+		if bytes.Compare(checkDec, raw) != 0 {
+			t.Errorf("Checksum decoding was incorrect: expected %v, got: %v", raw, checkDec)
+		}
+
+		// CheckDecode with the default checksum shall fail, unless it is the default one
+		_, err = New(AlphabetBitcoin).CheckDecode(currTest.CheckEnc)
+		if (err == nil) != (currTest.Chksum == ChecksumDoubleSha256) {
+			t.Errorf("Checksum decoding (%s) with default checksum returned wrong result", currTest.CheckEnc)
+		}
+	}
+
+	// Keccak-256 of empty data
+	if chksum := ChecksumKeccak256.Compute(nil); hex.EncodeToString(chksum) != "c5d24601" {
+		t.Errorf("Keccak-256 checksum was incorrect: expected c5d24601, got: %x", chksum)
+	}
+
+	// Custom hash checksum
+	chksum, err := NewHashChecksum(sha256Hash, 32, ChecksumHead)
+	if err != nil {
+		t.Fatalf("Creating hash checksum returned error: %s", err.Error())
+	}
+	base58Obj := New(AlphabetBitcoin).WithChecksum(chksum)
+	checkDec, err := base58Obj.CheckDecode(base58Obj.CheckEncode([]byte("test")))
+	if err != nil || string(checkDec) != "test" {
+		t.Errorf("Checksum decoding with custom checksum returned wrong result")
+	}
+	if _, err = base58Obj.CheckDecode(base58Obj.Encode(make([]byte, 31))); err != ErrInputTooShort {
+		t.Errorf("Checksum decoding of short input with custom checksum returned wrong error")
+	}
+
+	// Invalid checksum sizes
+	for _, size := range []int { -1, 0, 33 } {
+		if _, err := NewHashChecksum(sha256Hash, size, ChecksumHead); err != ErrInvalidChecksumSize {
+			t.Errorf("Creating hash checksum with invalid size %d returned wrong error", size)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the serialization of BIP32 extended keys, built on top of Base58Check.
+//
+
+// Package bip32 implements the serialization of BIP32 extended keys, with SLIP-132 version prefixes.
+package bip32
+
+//
+// Imports
+//
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Version length
+	VersionLen = 4
+	// Parent fingerprint length
+	FingerprintLen = 4
+	// Chain code length
+	ChainCodeLen = 32
+	// Key length (private keys are prefixed by 0x00)
+	KeyLen = 33
+	// Serialized extended key length
+	SerializedLen = VersionLen + 1 + FingerprintLen + 4 + ChainCodeLen + KeyLen
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidLength is returned when the serialized key length is not valid
+	ErrInvalidLength = errors.New("The extended key length is not valid")
+	// ErrUnknownVersion is returned when the version is not registered
+	ErrUnknownVersion = errors.New("The extended key version is not known")
+	// ErrInvalidPrivateKey is returned when the private key is not valid
+	ErrInvalidPrivateKey = errors.New("The private key is not valid")
+	// ErrInvalidPublicKey is returned when the public key is not valid
+	ErrInvalidPublicKey = errors.New("The public key is not valid")
+	// ErrInvalidMaster is returned when a master key (zero depth) has non-zero parent fingerprint or child index
+	ErrInvalidMaster = errors.New("The master key has non-zero parent fingerprint or child index")
+	// ErrVersionMismatch is returned when converting to a version of a different network or key kind
+	ErrVersionMismatch = errors.New("The target version does not match network or key kind")
+
+	// secp256k1 field prime
+	curveP, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	// secp256k1 curve order
+	curveN, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Extended key structure.
+type ExtendedKey struct {
+	Version           Version
+	Depth             byte
+	ParentFingerprint [FingerprintLen]byte
+	ChildIndex        uint32
+	ChainCode         [ChainCodeLen]byte
+	Key               [KeyLen]byte
+}
+
+//
+// Exported functions
+//
+
+// Decode the specified extended key string, validating its structure.
+func Decode(keyStr string) (*ExtendedKey, error) {
+	dec, err := base58Btc.CheckDecode(keyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(dec)
+}
+
+// Parse the specified serialized extended key (without checksum), validating its structure.
+func Parse(data []byte) (*ExtendedKey, error) {
+	if len(data) != SerializedLen {
+		return nil, ErrInvalidLength
+	}
+
+	key := &ExtendedKey {}
+	copy(key.Version[:], data)
+	key.Depth = data[VersionLen]
+	copy(key.ParentFingerprint[:], data[VersionLen + 1:])
+	key.ChildIndex = binary.BigEndian.Uint32(data[VersionLen + 1 + FingerprintLen:])
+	copy(key.ChainCode[:], data[VersionLen + 1 + FingerprintLen + 4:])
+	copy(key.Key[:], data[SerializedLen - KeyLen:])
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Validate the extended key structure.
+func (key *ExtendedKey) Validate() error {
+	info, ok := LookupVersion(key.Version)
+	if !ok {
+		return ErrUnknownVersion
+	}
+
+	// Master keys shall have zero parent fingerprint and child index
+	if key.Depth == 0 && (key.ParentFingerprint != [FingerprintLen]byte {} || key.ChildIndex != 0) {
+		return ErrInvalidMaster
+	}
+
+	if info.Private {
+		return validatePrivateKey(key.Key[:])
+	}
+	return validatePublicKey(key.Key[:])
+}
+
+// Get if the extended key is private.
+func (key *ExtendedKey) IsPrivate() bool {
+	info, ok := LookupVersion(key.Version)
+	return ok && info.Private
+}
+
+// Get a copy of the extended key with the version of the specified name (e.g. "xpub" for a "zpub" key).
+// The target version shall be of the same network and key kind (public/private).
+func (key *ExtendedKey) Convert(name string) (*ExtendedKey, error) {
+	info, ok := LookupVersion(key.Version)
+	if !ok {
+		return nil, ErrUnknownVersion
+	}
+	newInfo, ok := LookupName(name)
+	if !ok {
+		return nil, ErrUnknownVersion
+	}
+	if newInfo.Net != info.Net || newInfo.Private != info.Private {
+		return nil, ErrVersionMismatch
+	}
+
+	newKey := *key
+	newKey.Version = newInfo.Version
+
+	return &newKey, nil
+}
+
+// Serialize the extended key (without checksum).
+func (key *ExtendedKey) Serialize() []byte {
+	data := make([]byte, 0, SerializedLen)
+	data = append(data, key.Version[:]...)
+	data = append(data, key.Depth)
+	data = append(data, key.ParentFingerprint[:]...)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[len(data) - 4:], key.ChildIndex)
+	data = append(data, key.ChainCode[:]...)
+	data = append(data, key.Key[:]...)
+
+	return data
+}
+
+// Get the extended key string.
+func (key *ExtendedKey) String() string {
+	return base58Btc.CheckEncode(key.Serialize())
+}
+
+//
+// Not-exported functions
+//
+
+// Validate private key: it shall be prefixed by 0x00 and in the range [1, n-1]
+func validatePrivateKey(key []byte) error {
+	if key[0] != 0x00 {
+		return ErrInvalidPrivateKey
+	}
+
+	keyInt := new(big.Int).SetBytes(key[1:])
+	if keyInt.Sign() == 0 || keyInt.Cmp(curveN) >= 0 {
+		return ErrInvalidPrivateKey
+	}
+
+	return nil
+}
+
+// Validate compressed public key: it shall be prefixed by 0x02 or 0x03 and the point shall be on the curve
+func validatePublicKey(key []byte) error {
+	if key[0] != 0x02 && key[0] != 0x03 {
+		return ErrInvalidPublicKey
+	}
+
+	// x shall be lower than p
+	x := new(big.Int).SetBytes(key[1:])
+	if x.Cmp(curveP) >= 0 {
+		return ErrInvalidPublicKey
+	}
+
+	// x^3 + 7 shall be a quadratic residue modulo p
+	y2 := new(big.Int).Exp(x, big.NewInt(3), curveP)
+	y2.Add(y2, big.NewInt(7))
+	y2.Mod(y2, curveP)
+	if new(big.Int).ModSqrt(y2, curveP) == nil {
+		return ErrInvalidPublicKey
+	}
+
+	return nil
+}
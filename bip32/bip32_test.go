@@ -0,0 +1,242 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip32
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Key     string
+	Decoded string
+	Name    string
+	Private bool
+}
+
+//
+// Variables
+//
+
+// Valid extended keys (from BIP32, BIP49 and BIP84)
+var testVectValid = []testVectEntry {
+	testVectEntry {
+		Key:     "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		Decoded: "0488b21e000000000000000000873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d5080339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+		Name:    "xpub",
+		Private: false,
+	},
+	testVectEntry {
+		Key:     "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		Decoded: "0488ade4000000000000000000873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d50800e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		Name:    "xprv",
+		Private: true,
+	},
+	testVectEntry {
+		Key:     "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		Decoded: "0488b21e013442193e8000000047fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141035a784662a4a20a65bf6aab9ae98a6c068a81c52e4b032c0fb5400c706cfccc56",
+		Name:    "xpub",
+		Private: false,
+	},
+	testVectEntry {
+		Key:     "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+		Decoded: "0488ade4013442193e8000000047fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae623614100edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		Name:    "xprv",
+		Private: true,
+	},
+	testVectEntry {
+		Key:     "ypub6Ww3ibxVfGzLrAH1PNcjyAWenMTbbAosGNB6VvmSEgytSER9azLDWCxoJwW7Ke7icmizBMXrzBx9979FfaHxHcrArf3zbeJJJUZPf663zsP",
+		Decoded: "049d7cb2033d05ff75800000006eaae365ae0e0a0aab84325cfe7cd76c3b909035f889e7d3f1b847a9a0797ecb02f1f347891b20f7568eae3ec9869fbfb67bcab6f358326f10ecc42356bd55939d",
+		Name:    "ypub",
+		Private: false,
+	},
+	testVectEntry {
+		Key:     "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs",
+		Decoded: "04b24746037ef32bdb800000004a53a0ab21b9dc95869c4e92a161194e03c0ef3ff5014ac692f433c4765490fc02707a62fdacc26ea9b63b1c197906f56ee0180d0bcf1966e1a2da34f5f3a09a9b",
+		Name:    "zpub",
+		Private: false,
+	},
+}
+
+// Invalid extended keys
+var testVectInvalid = []string {
+	// Empty
+	"",
+	// Invalid checksum
+	"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet9",
+	// Public version with private key (from BIP32)
+	"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6LBpB85b3D2yc8sfvZU521AAwdZafEz7mnzBBsz4wKY5fTtTQBm",
+}
+
+//
+// Functions
+//
+
+// Test valid extended keys
+func TestValid(t *testing.T) {
+	for _, currTest := range testVectValid {
+		decoded, _ := hex.DecodeString(currTest.Decoded)
+
+		key, err := Decode(currTest.Key)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Key, err.Error())
+			continue
+		}
+		if !bytes.Equal(key.Serialize(), decoded) {
+			t.Errorf("Decoding (%s) was incorrect: got %x", currTest.Key, key.Serialize())
+		}
+		if key.IsPrivate() != currTest.Private {
+			t.Errorf("Decoding (%s) returned wrong key kind", currTest.Key)
+		}
+		if info, _ := LookupVersion(key.Version); info.Name != currTest.Name {
+			t.Errorf("Decoding (%s) returned wrong version: %s", currTest.Key, info.Name)
+		}
+		if key.String() != currTest.Key {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Key, key.String())
+		}
+	}
+}
+
+// Test invalid extended keys
+func TestInvalid(t *testing.T) {
+	for _, currTest := range testVectInvalid {
+		key, err := Decode(currTest)
+		if key != nil || err == nil {
+			t.Errorf("Decoding invalid extended key (%s) returned no error", currTest)
+		}
+	}
+
+	base58Btc := base58.New(base58.AlphabetBitcoin)
+	pub, _ := hex.DecodeString(testVectValid[2].Decoded)
+	priv, _ := hex.DecodeString(testVectValid[3].Decoded)
+
+	// Modify a copy of the specified payload and encode it
+	modify := func(data []byte, idx int, b ...byte) string {
+		mod := append([]byte {}, data...)
+		copy(mod[idx:], b)
+		return base58Btc.CheckEncode(mod)
+	}
+
+	testVectErr := []struct {
+		Key string
+		Err error
+	} {
+		// Invalid length
+		{ base58Btc.CheckEncode(pub[1:]), ErrInvalidLength },
+		{ base58Btc.CheckEncode(append(pub, 0x00)), ErrInvalidLength },
+		// Unknown version
+		{ modify(pub, 0, 0x01, 0x02, 0x03, 0x04), ErrUnknownVersion },
+		// Master key with non-zero parent fingerprint or child index
+		{ modify(pub, 4, 0x00), ErrInvalidMaster },
+		{ modify(pub, 4, 0x00, 0x00, 0x00, 0x00, 0x00), ErrInvalidMaster },
+		// Private key without 0x00 prefix
+		{ modify(priv, 45, 0x01), ErrInvalidPrivateKey },
+		// Private key equal to zero or to the curve order
+		{ modify(priv, 46, make([]byte, 32)...), ErrInvalidPrivateKey },
+		{ modify(priv, 46, curveN.Bytes()...), ErrInvalidPrivateKey },
+		// Public key with invalid prefix
+		{ modify(pub, 45, 0x04), ErrInvalidPublicKey },
+		// Public key not on the curve (x = 5, x^3 + 7 is not a square modulo p)
+		{ modify(pub, 45, append([]byte { 0x02 }, append(make([]byte, 31), 0x05)...)...), ErrInvalidPublicKey },
+	}
+
+	for _, currTest := range testVectErr {
+		if _, err := Decode(currTest.Key); err != currTest.Err {
+			t.Errorf("Decoding invalid extended key (%s) returned wrong error: %v", currTest.Key, err)
+		}
+	}
+}
+
+// Test version conversion
+func TestConvert(t *testing.T) {
+	key, _ := Decode(testVectValid[5].Key)
+
+	// zpub -> xpub -> zpub
+	xpub, err := key.Convert("xpub")
+	if err != nil {
+		t.Fatalf("Converting to xpub returned error: %s", err.Error())
+	}
+	if xpub.Key != key.Key || xpub.ChainCode != key.ChainCode || xpub.ChildIndex != key.ChildIndex || xpub.Depth != key.Depth {
+		t.Errorf("Converting to xpub modified the key material")
+	}
+	if xpub.String()[:4] != "xpub" || key.String()[:4] != "zpub" {
+		t.Errorf("Converting to xpub was incorrect: got %s", xpub.String())
+	}
+	zpub, _ := xpub.Convert("zpub")
+	if zpub.String() != testVectValid[5].Key {
+		t.Errorf("Converting back to zpub was incorrect: got %s", zpub.String())
+	}
+
+	// Different key kind or network
+	if _, err := key.Convert("zprv"); err != ErrVersionMismatch {
+		t.Errorf("Converting to private version returned wrong error")
+	}
+	if _, err := key.Convert("vpub"); err != ErrVersionMismatch {
+		t.Errorf("Converting to testnet version returned wrong error")
+	}
+	if _, err := key.Convert("abcd"); err != ErrUnknownVersion {
+		t.Errorf("Converting to unknown version returned wrong error")
+	}
+}
+
+// Test version registration
+func TestRegisterVersion(t *testing.T) {
+	info := VersionInfo {
+		Name:    "Ltub",
+		Version: Version { 0x01, 0x9d, 0xa4, 0x62 },
+		Net:     Mainnet,
+		Private: false,
+		Script:  "p2pkh",
+	}
+
+	if err := RegisterVersion(info); err != nil {
+		t.Fatalf("Registering version returned error: %s", err.Error())
+	}
+	if err := RegisterVersion(info); err != ErrVersionRegistered {
+		t.Errorf("Registering the same version twice returned wrong error")
+	}
+	if got, ok := LookupName("Ltub"); !ok || got != info {
+		t.Errorf("Looking up registered version was incorrect")
+	}
+
+	key, _ := Decode(testVectValid[0].Key)
+	ltub, err := key.Convert("Ltub")
+	if err != nil {
+		t.Fatalf("Converting to registered version returned error: %s", err.Error())
+	}
+	if ltub.String()[:4] != "Ltub" {
+		t.Errorf("Converting to registered version was incorrect: got %s", ltub.String())
+	}
+	if _, err = Decode(ltub.String()); err != nil {
+		t.Errorf("Decoding registered version returned error: %s", err.Error())
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the registry of extended key versions (BIP32 and SLIP-132).
+//
+
+package bip32
+
+//
+// Imports
+//
+import (
+	"errors"
+	"sync"
+)
+
+//
+// Constants
+//
+const (
+	// Bitcoin mainnet
+	Mainnet Network = 0
+	// Bitcoin testnet
+	Testnet Network = 1
+)
+
+//
+// Variables
+//
+var (
+	// ErrVersionRegistered is returned when registering a version or name already registered
+	ErrVersionRegistered = errors.New("The specified version or name is already registered")
+
+	// Registered versions (SLIP-132)
+	versions = []VersionInfo {
+		// Mainnet
+		VersionInfo { "xpub", Version { 0x04, 0x88, 0xb2, 0x1e }, Mainnet, false, "p2pkh" },
+		VersionInfo { "xprv", Version { 0x04, 0x88, 0xad, 0xe4 }, Mainnet, true,  "p2pkh" },
+		VersionInfo { "ypub", Version { 0x04, 0x9d, 0x7c, 0xb2 }, Mainnet, false, "p2wpkh-p2sh" },
+		VersionInfo { "yprv", Version { 0x04, 0x9d, 0x78, 0x78 }, Mainnet, true,  "p2wpkh-p2sh" },
+		VersionInfo { "Ypub", Version { 0x02, 0x95, 0xb4, 0x3f }, Mainnet, false, "p2wsh-p2sh" },
+		VersionInfo { "Yprv", Version { 0x02, 0x95, 0xb0, 0x05 }, Mainnet, true,  "p2wsh-p2sh" },
+		VersionInfo { "zpub", Version { 0x04, 0xb2, 0x47, 0x46 }, Mainnet, false, "p2wpkh" },
+		VersionInfo { "zprv", Version { 0x04, 0xb2, 0x43, 0x0c }, Mainnet, true,  "p2wpkh" },
+		VersionInfo { "Zpub", Version { 0x02, 0xaa, 0x7e, 0xd3 }, Mainnet, false, "p2wsh" },
+		VersionInfo { "Zprv", Version { 0x02, 0xaa, 0x7a, 0x99 }, Mainnet, true,  "p2wsh" },
+		// Testnet
+		VersionInfo { "tpub", Version { 0x04, 0x35, 0x87, 0xcf }, Testnet, false, "p2pkh" },
+		VersionInfo { "tprv", Version { 0x04, 0x35, 0x83, 0x94 }, Testnet, true,  "p2pkh" },
+		VersionInfo { "upub", Version { 0x04, 0x4a, 0x52, 0x62 }, Testnet, false, "p2wpkh-p2sh" },
+		VersionInfo { "uprv", Version { 0x04, 0x4a, 0x4e, 0x28 }, Testnet, true,  "p2wpkh-p2sh" },
+		VersionInfo { "Upub", Version { 0x02, 0x42, 0x89, 0xef }, Testnet, false, "p2wsh-p2sh" },
+		VersionInfo { "Uprv", Version { 0x02, 0x42, 0x85, 0xb5 }, Testnet, true,  "p2wsh-p2sh" },
+		VersionInfo { "vpub", Version { 0x04, 0x5f, 0x1c, 0xf6 }, Testnet, false, "p2wpkh" },
+		VersionInfo { "vprv", Version { 0x04, 0x5f, 0x18, 0xbc }, Testnet, true,  "p2wpkh" },
+		VersionInfo { "Vpub", Version { 0x02, 0x57, 0x54, 0x83 }, Testnet, false, "p2wsh" },
+		VersionInfo { "Vprv", Version { 0x02, 0x57, 0x50, 0x48 }, Testnet, true,  "p2wsh" },
+	}
+
+	versionsMutex sync.RWMutex
+)
+
+//
+// Types
+//
+
+// Network type
+type Network int
+
+// Version prefix of an extended key
+type Version [VersionLen]byte
+
+// Version information structure
+type VersionInfo struct {
+	// Name of the version, i.e. the human-readable prefix (e.g. "xpub")
+	Name    string
+	// Version bytes
+	Version Version
+	// Network
+	Net     Network
+	// True for private keys, false for public keys
+	Private bool
+	// Script type (e.g. "p2pkh", "p2wpkh")
+	Script  string
+}
+
+//
+// Exported functions
+//
+
+// Register a new version (e.g. for altcoins).
+// It returns ErrVersionRegistered if the version bytes or the name are already registered.
+func RegisterVersion(info VersionInfo) error {
+	versionsMutex.Lock()
+	defer versionsMutex.Unlock()
+
+	for _, currInfo := range versions {
+		if currInfo.Version == info.Version || currInfo.Name == info.Name {
+			return ErrVersionRegistered
+		}
+	}
+	versions = append(versions, info)
+
+	return nil
+}
+
+// Get the information of the specified version bytes.
+func LookupVersion(version Version) (VersionInfo, bool) {
+	versionsMutex.RLock()
+	defer versionsMutex.RUnlock()
+
+	for _, info := range versions {
+		if info.Version == version {
+			return info, true
+		}
+	}
+	return VersionInfo {}, false
+}
+
+// Get the information of the version with the specified name (e.g. "zpub").
+func LookupName(name string) (VersionInfo, bool) {
+	versionsMutex.RLock()
+	defer versionsMutex.RUnlock()
+
+	for _, info := range versions {
+		if info.Name == name {
+			return info, true
+		}
+	}
+	return VersionInfo {}, false
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the identification of Base58Check strings from their version bytes.
+//
+
+// Package identify guesses the type of Base58Check strings (addresses, keys, ...) from their alphabet, version bytes and length.
+package identify
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidPrefix is returned when registering a prefix with missing fields
+	ErrInvalidPrefix = errors.New("The specified prefix is not valid")
+	// ErrPrefixRegistered is returned when registering a prefix already registered
+	ErrPrefixRegistered = errors.New("The specified prefix is already registered")
+
+	// Built-in alphabets to try
+	alphabets = []int {
+		base58.AlphabetBitcoin,
+		base58.AlphabetRipple,
+		base58.AlphabetFlickr,
+	}
+
+	// Registered prefixes
+	prefixes = []Prefix {
+		// Bitcoin
+		Prefix { "Bitcoin", "P2PKH", base58.AlphabetBitcoin, []byte { 0x00 }, 20, nil },
+		Prefix { "Bitcoin", "P2SH", base58.AlphabetBitcoin, []byte { 0x05 }, 20, nil },
+		Prefix { "Bitcoin", "WIF", base58.AlphabetBitcoin, []byte { 0x80 }, 32, nil },
+		Prefix { "Bitcoin", "WIF (compressed)", base58.AlphabetBitcoin, []byte { 0x80 }, 33, []byte { 0x01 } },
+		Prefix { "Bitcoin", "xpub", base58.AlphabetBitcoin, []byte { 0x04, 0x88, 0xb2, 0x1e }, 74, nil },
+		Prefix { "Bitcoin", "xprv", base58.AlphabetBitcoin, []byte { 0x04, 0x88, 0xad, 0xe4 }, 74, nil },
+		Prefix { "Bitcoin", "ypub", base58.AlphabetBitcoin, []byte { 0x04, 0x9d, 0x7c, 0xb2 }, 74, nil },
+		Prefix { "Bitcoin", "yprv", base58.AlphabetBitcoin, []byte { 0x04, 0x9d, 0x78, 0x78 }, 74, nil },
+		Prefix { "Bitcoin", "zpub", base58.AlphabetBitcoin, []byte { 0x04, 0xb2, 0x47, 0x46 }, 74, nil },
+		Prefix { "Bitcoin", "zprv", base58.AlphabetBitcoin, []byte { 0x04, 0xb2, 0x43, 0x0c }, 74, nil },
+		// Bitcoin testnet
+		Prefix { "Bitcoin testnet", "P2PKH", base58.AlphabetBitcoin, []byte { 0x6f }, 20, nil },
+		Prefix { "Bitcoin testnet", "P2SH", base58.AlphabetBitcoin, []byte { 0xc4 }, 20, nil },
+		Prefix { "Bitcoin testnet", "WIF", base58.AlphabetBitcoin, []byte { 0xef }, 32, nil },
+		Prefix { "Bitcoin testnet", "WIF (compressed)", base58.AlphabetBitcoin, []byte { 0xef }, 33, []byte { 0x01 } },
+		Prefix { "Bitcoin testnet", "tpub", base58.AlphabetBitcoin, []byte { 0x04, 0x35, 0x87, 0xcf }, 74, nil },
+		Prefix { "Bitcoin testnet", "tprv", base58.AlphabetBitcoin, []byte { 0x04, 0x35, 0x83, 0x94 }, 74, nil },
+		// Litecoin
+		Prefix { "Litecoin", "P2PKH", base58.AlphabetBitcoin, []byte { 0x30 }, 20, nil },
+		Prefix { "Litecoin", "P2SH", base58.AlphabetBitcoin, []byte { 0x32 }, 20, nil },
+		Prefix { "Litecoin", "WIF", base58.AlphabetBitcoin, []byte { 0xb0 }, 32, nil },
+		Prefix { "Litecoin", "WIF (compressed)", base58.AlphabetBitcoin, []byte { 0xb0 }, 33, []byte { 0x01 } },
+		// Dogecoin
+		Prefix { "Dogecoin", "P2PKH", base58.AlphabetBitcoin, []byte { 0x1e }, 20, nil },
+		Prefix { "Dogecoin", "P2SH", base58.AlphabetBitcoin, []byte { 0x16 }, 20, nil },
+		Prefix { "Dogecoin", "WIF", base58.AlphabetBitcoin, []byte { 0x9e }, 32, nil },
+		Prefix { "Dogecoin", "WIF (compressed)", base58.AlphabetBitcoin, []byte { 0x9e }, 33, []byte { 0x01 } },
+		// Dash
+		Prefix { "Dash", "P2PKH", base58.AlphabetBitcoin, []byte { 0x4c }, 20, nil },
+		Prefix { "Dash", "P2SH", base58.AlphabetBitcoin, []byte { 0x10 }, 20, nil },
+		Prefix { "Dash", "WIF", base58.AlphabetBitcoin, []byte { 0xcc }, 32, nil },
+		Prefix { "Dash", "WIF (compressed)", base58.AlphabetBitcoin, []byte { 0xcc }, 33, []byte { 0x01 } },
+		// Zcash
+		Prefix { "Zcash", "P2PKH", base58.AlphabetBitcoin, []byte { 0x1c, 0xb8 }, 20, nil },
+		Prefix { "Zcash", "P2SH", base58.AlphabetBitcoin, []byte { 0x1c, 0xbd }, 20, nil },
+		// Tron
+		Prefix { "Tron", "Address", base58.AlphabetBitcoin, []byte { 0x41 }, 20, nil },
+		// Ripple
+		Prefix { "Ripple", "Account", base58.AlphabetRipple, []byte { 0x00 }, 20, nil },
+		Prefix { "Ripple", "Seed", base58.AlphabetRipple, []byte { 0x21 }, 16, nil },
+		Prefix { "Ripple", "Seed (ed25519)", base58.AlphabetRipple, []byte { 0x01, 0xe1, 0x4b }, 16, nil },
+		Prefix { "Ripple", "Node public key", base58.AlphabetRipple, []byte { 0x1c }, 33, nil },
+	}
+	prefixesMutex sync.RWMutex
+)
+
+//
+// Types
+//
+
+// Prefix structure, describing a kind of Base58Check string
+type Prefix struct {
+	// Network name (e.g. "Bitcoin")
+	Network    string
+	// Type name (e.g. "P2PKH")
+	Type       string
+	// Built-in alphabet index
+	AlphIdx    int
+	// Version bytes
+	Version    []byte
+	// Payload length, excluding version and checksum
+	PayloadLen int
+	// Payload suffix bytes (e.g. the compression flag of WIF keys), empty if not required
+	Suffix     []byte
+}
+
+// Candidate structure, describing a possible identification of a string
+type Candidate struct {
+	// Network name
+	Network  string
+	// Type name
+	Type     string
+	// Alphabet name
+	Alphabet string
+	// Version bytes
+	Version  []byte
+	// Payload, excluding version and checksum
+	Payload  []byte
+}
+
+//
+// Exported functions
+//
+
+// Register a new prefix.
+// It returns ErrPrefixRegistered if a prefix with the same network and type is already registered.
+func Register(prefix Prefix) error {
+	if prefix.Network == "" || prefix.Type == "" || len(prefix.Version) == 0 || prefix.PayloadLen <= 0 ||
+	   len(prefix.Suffix) > prefix.PayloadLen {
+		return ErrInvalidPrefix
+	}
+	if _, err := base58.BuiltinAlphabet(prefix.AlphIdx); err != nil {
+		return err
+	}
+
+	prefixesMutex.Lock()
+	defer prefixesMutex.Unlock()
+
+	for _, currPrefix := range prefixes {
+		if currPrefix.Network == prefix.Network && currPrefix.Type == prefix.Type {
+			return ErrPrefixRegistered
+		}
+	}
+	prefix.Version = append([]byte {}, prefix.Version...)
+	prefix.Suffix = append([]byte {}, prefix.Suffix...)
+	prefixes = append(prefixes, prefix)
+
+	return nil
+}
+
+// Identify the specified string.
+// The string is decoded with each built-in alphabet and, if the checksum is valid, its version bytes,
+// payload length and payload suffix are matched against the registered prefixes.
+// Candidates are ranked from the most specific (i.e. longest version) to the least specific, then
+// by registration order. An empty slice is returned if the string cannot be identified.
+func Identify(s string) []Candidate {
+	prefixesMutex.RLock()
+	defer prefixesMutex.RUnlock()
+
+	candidates := []Candidate {}
+	for _, alphIdx := range alphabets {
+		dec, err := base58.New(alphIdx).CheckDecode(s)
+		if err != nil {
+			continue
+		}
+		alph, _ := base58.BuiltinAlphabet(alphIdx)
+
+		for _, prefix := range prefixes {
+			if prefix.AlphIdx != alphIdx ||
+			   len(dec) != len(prefix.Version) + prefix.PayloadLen ||
+			   !bytes.HasPrefix(dec, prefix.Version) ||
+			   !bytes.HasSuffix(dec, prefix.Suffix) {
+				continue
+			}
+			candidates = append(candidates, Candidate {
+				Network:  prefix.Network,
+				Type:     prefix.Type,
+				Alphabet: alph.Name(),
+				// Copy version and payload, so that candidates do not share memory
+				Version:  append([]byte {}, dec[:len(prefix.Version)]...),
+				Payload:  append([]byte {}, dec[len(prefix.Version):]...),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i].Version) > len(candidates[j].Version)
+	})
+
+	return candidates
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package identify
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Str     string
+	Network string
+	Type    string
+}
+
+//
+// Variables
+//
+
+// Known strings
+var testVectKnown = []testVectEntry {
+	testVectEntry { "1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i", "Bitcoin", "P2PKH" },
+	testVectEntry { "3CMNFxN1oHBc4R1EpboAL5yzHGgE611Xou", "Bitcoin", "P2SH" },
+	testVectEntry { "mo9ncXisMeAoXwqcV5EWuyncbmCcQN4rVs", "Bitcoin testnet", "P2PKH" },
+	testVectEntry { "2N2JD6wb56AfK4tfmM6PwdVmoYk2dCKf4Br", "Bitcoin testnet", "P2SH" },
+	testVectEntry { "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", "Bitcoin", "WIF" },
+	testVectEntry { "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617", "Bitcoin", "WIF (compressed)" },
+	testVectEntry { "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", "Bitcoin", "xpub" },
+	testVectEntry { "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", "Bitcoin", "xprv" },
+	testVectEntry { "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs", "Bitcoin", "zpub" },
+	testVectEntry { "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "Tron", "Address" },
+	testVectEntry { "t1StbPM4X3j4FGM57HpGnb9BMbS7C1nFW1r", "Zcash", "P2PKH" },
+	testVectEntry { "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Ripple", "Account" },
+	testVectEntry { "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "Ripple", "Seed" },
+}
+
+// Generated strings (alphabet, version and payload length)
+var testVectGenerated = []struct {
+	AlphIdx    int
+	Version    []byte
+	PayloadLen int
+	Network    string
+	Type       string
+} {
+	{ base58.AlphabetBitcoin, []byte { 0x30 }, 20, "Litecoin", "P2PKH" },
+	{ base58.AlphabetBitcoin, []byte { 0x32 }, 20, "Litecoin", "P2SH" },
+	{ base58.AlphabetBitcoin, []byte { 0xb0 }, 33, "Litecoin", "WIF (compressed)" },
+	{ base58.AlphabetBitcoin, []byte { 0x1e }, 20, "Dogecoin", "P2PKH" },
+	{ base58.AlphabetBitcoin, []byte { 0x16 }, 20, "Dogecoin", "P2SH" },
+	{ base58.AlphabetBitcoin, []byte { 0x9e }, 32, "Dogecoin", "WIF" },
+	{ base58.AlphabetBitcoin, []byte { 0x4c }, 20, "Dash", "P2PKH" },
+	{ base58.AlphabetBitcoin, []byte { 0x10 }, 20, "Dash", "P2SH" },
+	{ base58.AlphabetBitcoin, []byte { 0xcc }, 32, "Dash", "WIF" },
+	{ base58.AlphabetBitcoin, []byte { 0x04, 0x35, 0x87, 0xcf }, 74, "Bitcoin testnet", "tpub" },
+	{ base58.AlphabetRipple, []byte { 0x01, 0xe1, 0x4b }, 16, "Ripple", "Seed (ed25519)" },
+	{ base58.AlphabetRipple, []byte { 0x1c }, 33, "Ripple", "Node public key" },
+}
+
+// Not identifiable strings
+var testVectUnknown = []string {
+	"",
+	"1",
+	// Invalid checksum
+	"1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62j",
+	// Valid checksum, unknown version
+	"2mcBp5h1U41ARYXZ6EywGeyQcVdhYP7dFob",
+	// Valid checksum, wrong length
+	"3EFU7m",
+	// Valid checksum, WIF with wrong compression suffix
+	"L2mhxzpxpJkABa7hnz1mbPeBqindH54Ti1YwkVomX9YWLTEmVLfV",
+	// Invalid characters for all alphabets
+	"0OIl",
+}
+
+//
+// Functions
+//
+
+// Test known strings
+func TestKnown(t *testing.T) {
+	for _, currTest := range testVectKnown {
+		candidates := Identify(currTest.Str)
+		if len(candidates) == 0 {
+			t.Errorf("Identifying (%s) returned no candidates", currTest.Str)
+			continue
+		}
+		if candidates[0].Network != currTest.Network || candidates[0].Type != currTest.Type {
+			t.Errorf("Identifying (%s) was incorrect: expected %s %s, got: %s %s",
+			         currTest.Str, currTest.Network, currTest.Type, candidates[0].Network, candidates[0].Type)
+		}
+	}
+
+	// Check alphabet and payload
+	candidates := Identify("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	if len(candidates) != 1 || candidates[0].Alphabet != "Ripple" || len(candidates[0].Payload) != 20 {
+		t.Errorf("Identifying Ripple account was incorrect: got %v", candidates)
+	}
+}
+
+// Test generated strings
+func TestGenerated(t *testing.T) {
+	for _, currTest := range testVectGenerated {
+		// Terminate payload with the WIF compression suffix, so that it matches also compressed keys
+		payload := append(bytes.Repeat([]byte { 0xa5 }, currTest.PayloadLen - 1), 0x01)
+		str := base58.New(currTest.AlphIdx).CheckEncodeVersion(currTest.Version, payload)
+
+		candidates := Identify(str)
+		if len(candidates) == 0 {
+			t.Errorf("Identifying (%s) returned no candidates", str)
+			continue
+		}
+		if candidates[0].Network != currTest.Network || candidates[0].Type != currTest.Type {
+			t.Errorf("Identifying (%s) was incorrect: expected %s %s, got: %s %s",
+			         str, currTest.Network, currTest.Type, candidates[0].Network, candidates[0].Type)
+		}
+		if !bytes.Equal(candidates[0].Version, currTest.Version) || !bytes.Equal(candidates[0].Payload, payload) {
+			t.Errorf("Identifying (%s) returned wrong version or payload", str)
+		}
+	}
+}
+
+// Test not identifiable strings
+func TestUnknown(t *testing.T) {
+	for _, currTest := range testVectUnknown {
+		if candidates := Identify(currTest); len(candidates) != 0 {
+			t.Errorf("Identifying (%s) returned candidates: %v", currTest, candidates)
+		}
+	}
+}
+
+// Test prefix registration and ranking
+func TestRegister(t *testing.T) {
+	prefix := Prefix {
+		Network:    "Custom",
+		Type:       "Test",
+		AlphIdx:    base58.AlphabetBitcoin,
+		Version:    []byte { 0x00, 0x01 },
+		PayloadLen: 19,
+	}
+
+	if err := Register(prefix); err != nil {
+		t.Fatalf("Registering prefix returned error: %s", err.Error())
+	}
+	if err := Register(prefix); err != ErrPrefixRegistered {
+		t.Errorf("Registering the same prefix twice returned wrong error")
+	}
+
+	// The registered prefix is more specific than Bitcoin P2PKH, so it shall be ranked first
+	payload := append([]byte { 0x01 }, bytes.Repeat([]byte { 0x5a }, 19)...)
+	candidates := Identify(base58.New(base58.AlphabetBitcoin).CheckEncodeVersion([]byte { 0x00 }, payload))
+	if len(candidates) != 2 ||
+	   candidates[0].Network != "Custom" || candidates[0].Type != "Test" ||
+	   candidates[1].Network != "Bitcoin" || candidates[1].Type != "P2PKH" {
+		t.Errorf("Identifying registered prefix was incorrect: got %v", candidates)
+	}
+
+	// Candidates shall not share memory
+	payloadCopy := append([]byte {}, candidates[0].Payload...)
+	_ = append(candidates[0].Version, 0xff)
+	candidates[1].Payload[0] = 0xff
+	if !bytes.Equal(candidates[0].Payload, payloadCopy) {
+		t.Errorf("Modifying a candidate modified another one")
+	}
+
+	// Invalid prefixes
+	invalidPrefixes := []Prefix {
+		Prefix { "", "Test", base58.AlphabetBitcoin, []byte { 0x01 }, 20, nil },
+		Prefix { "Custom", "", base58.AlphabetBitcoin, []byte { 0x01 }, 20, nil },
+		Prefix { "Custom", "Test2", base58.AlphabetBitcoin, nil, 20, nil },
+		Prefix { "Custom", "Test2", base58.AlphabetBitcoin, []byte { 0x01 }, 0, nil },
+		Prefix { "Custom", "Test2", base58.AlphabetBitcoin, []byte { 0x01 }, 1, []byte { 0x01, 0x02 } },
+	}
+	for _, currPrefix := range invalidPrefixes {
+		if err := Register(currPrefix); err != ErrInvalidPrefix {
+			t.Errorf("Registering invalid prefix (%v) returned wrong error", currPrefix)
+		}
+	}
+	if err := Register(Prefix { "Custom", "Test2", 10, []byte { 0x01 }, 20, nil }); err != base58.ErrInvalidAlphabet {
+		t.Errorf("Registering prefix with invalid alphabet returned wrong error")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains a minimal implementation of the BLAKE2b hash function (RFC 7693), without key support.
+//
+
+// Package blake2b implements the BLAKE2b-512 hash function, as used by Substrate.
+package blake2b
+
+//
+// Imports
+//
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+//
+// Constants
+//
+const (
+	// Digest size in bytes
+	Size = 64
+	// Block size in bytes
+	blockSize = 128
+	// Number of rounds
+	roundsNum = 12
+)
+
+//
+// Variables
+//
+var (
+	// Initialization vector
+	iv = [8]uint64 {
+		0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	// Message schedule
+	sigma = [10][16]byte {
+		{ 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15 },
+		{ 14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3 },
+		{ 11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4 },
+		{ 7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8 },
+		{ 9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13 },
+		{ 2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9 },
+		{ 12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11 },
+		{ 13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10 },
+		{ 6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5 },
+		{ 10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0 },
+	}
+)
+
+//
+// Exported functions
+//
+
+// Compute the BLAKE2b-512 digest of the specified data.
+func Sum512(data []byte) [Size]byte {
+	// Initialize state with digest length, no key
+	h := iv
+	h[0] ^= 0x01010000 ^ uint64(Size)
+
+	// Compress all blocks but the last one
+	var counter uint64
+	for len(data) > blockSize {
+		counter += blockSize
+		compress(&h, data[:blockSize], counter, false)
+		data = data[blockSize:]
+	}
+
+	// Compress last block, padded with zeros
+	var block [blockSize]byte
+	copy(block[:], data)
+	counter += uint64(len(data))
+	compress(&h, block[:], counter, true)
+
+	// Get digest
+	var digest [Size]byte
+	for i := 0; i < 8; i++ {
+		binary.LittleEndian.PutUint64(digest[i * 8:], h[i])
+	}
+
+	return digest
+}
+
+//
+// Not-exported functions
+//
+
+// Compression function.
+// The counter is limited to 64-bit, which is enough for any in-memory data.
+func compress(h *[8]uint64, block []byte, counter uint64, last bool) {
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[i * 8:])
+	}
+
+	// Initialize working vector
+	var v [16]uint64
+	copy(v[:8], h[:])
+	copy(v[8:], iv[:])
+	v[12] ^= counter
+	if last {
+		v[14] = ^v[14]
+	}
+
+	for round := 0; round < roundsNum; round++ {
+		s := &sigma[round % 10]
+		mix(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		mix(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		mix(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		mix(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		mix(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		mix(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		mix(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		mix(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := 0; i < 8; i++ {
+		h[i] ^= v[i] ^ v[i + 8]
+	}
+}
+
+// Mixing function G
+func mix(v *[16]uint64, a, b, c, d int, x, y uint64) {
+	v[a] = v[a] + v[b] + x
+	v[d] = bits.RotateLeft64(v[d] ^ v[a], -32)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b] ^ v[c], -24)
+	v[a] = v[a] + v[b] + y
+	v[d] = bits.RotateLeft64(v[d] ^ v[a], -16)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b] ^ v[c], -63)
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains a minimal implementation of the (legacy) Keccak-256 hash function.
+// It differs from the standard SHA3-256 only for the padding byte.
+//
+
+// Package keccak implements the Keccak-256 hash function, as used by Ethereum, Tron and Monero.
+package keccak
+
+//
+// Imports
+//
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+//
+// Constants
+//
+const (
+	// Digest size in bytes
+	Size = 32
+	// Rate in bytes (1600 - 2 * 256 bits)
+	rate = 136
+	// Number of rounds
+	roundsNum = 24
+)
+
+//
+// Variables
+//
+var (
+	// Round constants
+	roundConstants = [roundsNum]uint64 {
+		0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+		0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+		0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+		0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+		0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+		0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+	}
+	// Rotation offsets, indexed by x + 5y
+	rotationOffsets = [25]int {
+		0, 1, 62, 28, 27,
+		36, 44, 6, 55, 20,
+		3, 10, 43, 25, 39,
+		41, 45, 15, 21, 8,
+		18, 2, 61, 56, 14,
+	}
+)
+
+//
+// Exported functions
+//
+
+// Compute the Keccak-256 digest of the specified data.
+func Sum256(data []byte) [Size]byte {
+	var state [25]uint64
+
+	// Absorb full blocks
+	for len(data) >= rate {
+		absorbBlock(&state, data[:rate])
+		data = data[rate:]
+	}
+
+	// Absorb last block with padding
+	var block [rate]byte
+	copy(block[:], data)
+	block[len(data)] ^= 0x01
+	block[rate - 1] ^= 0x80
+	absorbBlock(&state, block[:])
+
+	// Squeeze digest
+	var digest [Size]byte
+	for i := 0; i < Size / 8; i++ {
+		binary.LittleEndian.PutUint64(digest[i * 8:], state[i])
+	}
+
+	return digest
+}
+
+//
+// Not-exported functions
+//
+
+// Absorb a block of rate bytes into the state
+func absorbBlock(state *[25]uint64, block []byte) {
+	for i := 0; i < rate / 8; i++ {
+		state[i] ^= binary.LittleEndian.Uint64(block[i * 8:])
+	}
+	keccakF1600(state)
+}
+
+// Keccak-f[1600] permutation
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	var b [25]uint64
+
+	for round := 0; round < roundsNum; round++ {
+		// Theta step
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x + 5] ^ a[x + 10] ^ a[x + 15] ^ a[x + 20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x + 4) % 5] ^ bits.RotateLeft64(c[(x + 1) % 5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[x + y] ^= d
+			}
+		}
+
+		// Rho and pi steps
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y + 5 * ((2 * x + 3 * y) % 5)] = bits.RotateLeft64(a[x + 5 * y], rotationOffsets[x + 5 * y])
+			}
+		}
+
+		// Chi step
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[x + y] = b[x + y] ^ (^b[(x + 1) % 5 + y] & b[(x + 2) % 5 + y])
+			}
+		}
+
+		// Iota step
+		a[0] ^= roundConstants[round]
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Monero addresses, built on top of the Base58 block encoding with Keccak-256 checksum.
+//
+
+// Package monero implements Monero standard addresses, integrated addresses and subaddresses.
+package monero
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Monero mainnet
+	Mainnet Network = 0
+	// Monero testnet
+	Testnet Network = 1
+	// Monero stagenet
+	Stagenet Network = 2
+
+	// Standard address
+	Standard Type = 0
+	// Integrated address (standard address with payment ID)
+	Integrated Type = 1
+	// Subaddress
+	Subaddress Type = 2
+
+	// Public key length
+	KeyLen = 32
+	// Payment ID length
+	PaymentIDLen = 8
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidNetwork is returned when using a not-existent network
+	ErrInvalidNetwork = errors.New("The specified network is not existent")
+	// ErrInvalidType is returned when using a not-existent address type
+	ErrInvalidType = errors.New("The specified address type is not existent")
+	// ErrInvalidTag is returned when the network tag is not valid
+	ErrInvalidTag = errors.New("The address network tag is not valid")
+	// ErrInvalidLength is returned when the address or key length is not valid
+	ErrInvalidLength = errors.New("The address length is not valid")
+	// ErrInvalidPaymentID is returned when the payment ID is not valid for the address type
+	ErrInvalidPaymentID = errors.New("The payment ID is not valid for the address type")
+
+	// Map from network and address type to network tag
+	tagMap = map[Network]map[Type]uint64 {
+		Mainnet: {
+			Standard:   18,
+			Integrated: 19,
+			Subaddress: 42,
+		},
+		Testnet: {
+			Standard:   53,
+			Integrated: 54,
+			Subaddress: 63,
+		},
+		Stagenet: {
+			Standard:   24,
+			Integrated: 25,
+			Subaddress: 36,
+		},
+	}
+
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Network type
+type Network int
+
+// Address type
+type Type int
+
+// Address structure.
+// The payment ID is only meaningful for integrated addresses.
+type Address struct {
+	Net       Network
+	Type      Type
+	SpendKey  [KeyLen]byte
+	ViewKey   [KeyLen]byte
+	PaymentID [PaymentIDLen]byte
+}
+
+//
+// Exported functions
+//
+
+// Create a new address from the specified network, type, public keys and payment ID.
+// The payment ID shall be specified only for integrated addresses.
+func New(net Network, addrType Type, spendKey []byte, viewKey []byte, paymentID []byte) (*Address, error) {
+	if _, err := getTag(net, addrType); err != nil {
+		return nil, err
+	}
+	if len(spendKey) != KeyLen || len(viewKey) != KeyLen {
+		return nil, ErrInvalidLength
+	}
+	paymentIDLen := 0
+	if addrType == Integrated {
+		paymentIDLen = PaymentIDLen
+	}
+	if len(paymentID) != paymentIDLen {
+		return nil, ErrInvalidPaymentID
+	}
+
+	addr := &Address {
+		Net:  net,
+		Type: addrType,
+	}
+	copy(addr.SpendKey[:], spendKey)
+	copy(addr.ViewKey[:], viewKey)
+	copy(addr.PaymentID[:], paymentID)
+
+	return addr, nil
+}
+
+// Decode the specified address.
+func Decode(addrStr string) (*Address, error) {
+	dec, err := base58Btc.BlockDecode(addrStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get network and type from tag, that shall be minimally encoded
+	tag, n := binary.Uvarint(dec)
+	if n <= 0 || (n > 1 && dec[n - 1] == 0) {
+		return nil, ErrInvalidTag
+	}
+	net, addrType, err := getNetworkAndType(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check length
+	chksumLen := base58.ChecksumKeccak256.Size()
+	payloadLen := 2 * KeyLen
+	if addrType == Integrated {
+		payloadLen += PaymentIDLen
+	}
+	if len(dec) != n + payloadLen + chksumLen {
+		return nil, ErrInvalidLength
+	}
+
+	// Verify checksum
+	chksumIdx := len(dec) - chksumLen
+	if !bytes.Equal(dec[chksumIdx:], base58.ChecksumKeccak256.Compute(dec[:chksumIdx])) {
+		return nil, base58.ErrInvalidChecksum
+	}
+
+	payload := dec[n:chksumIdx]
+	return New(net, addrType, payload[:KeyLen], payload[KeyLen:2 * KeyLen], payload[2 * KeyLen:])
+}
+
+// Encode the address.
+// It returns an error if the network or type is not valid.
+func (addr *Address) Encode() (string, error) {
+	tag, err := getTag(addr.Net, addr.Type)
+	if err != nil {
+		return "", err
+	}
+
+	data := make([]byte, 0, binary.MaxVarintLen64 + 2 * KeyLen + PaymentIDLen + base58.ChecksumKeccak256.Size())
+	var tagBuf [binary.MaxVarintLen64]byte
+	data = append(data, tagBuf[:binary.PutUvarint(tagBuf[:], tag)]...)
+	data = append(data, addr.SpendKey[:]...)
+	data = append(data, addr.ViewKey[:]...)
+	if addr.Type == Integrated {
+		data = append(data, addr.PaymentID[:]...)
+	}
+	data = append(data, base58.ChecksumKeccak256.Compute(data)...)
+
+	return base58Btc.BlockEncode(data), nil
+}
+
+// Get the address string.
+// It returns an empty string if the network or type is not valid, use Encode for getting the error.
+func (addr *Address) String() string {
+	enc, _ := addr.Encode()
+	return enc
+}
+
+// Get the standard address of an integrated address (i.e. without payment ID).
+// It returns ErrInvalidType if the address is not integrated.
+func (addr *Address) Standard() (*Address, error) {
+	if addr.Type != Integrated {
+		return nil, ErrInvalidType
+	}
+	return New(addr.Net, Standard, addr.SpendKey[:], addr.ViewKey[:], nil)
+}
+
+// Get the integrated address of a standard address with the specified payment ID.
+// It returns ErrInvalidType if the address is not standard.
+func (addr *Address) Integrated(paymentID []byte) (*Address, error) {
+	if addr.Type != Standard {
+		return nil, ErrInvalidType
+	}
+	return New(addr.Net, Integrated, addr.SpendKey[:], addr.ViewKey[:], paymentID)
+}
+
+//
+// Not-exported functions
+//
+
+// Get the network tag from network and address type
+func getTag(net Network, addrType Type) (uint64, error) {
+	netTags, ok := tagMap[net]
+	if !ok {
+		return 0, ErrInvalidNetwork
+	}
+	tag, ok := netTags[addrType]
+	if !ok {
+		return 0, ErrInvalidType
+	}
+	return tag, nil
+}
+
+// Get network and address type from the network tag
+func getNetworkAndType(tag uint64) (Network, Type, error) {
+	for net, netTags := range tagMap {
+		for addrType, currTag := range netTags {
+			if currTag == tag {
+				return net, addrType, nil
+			}
+		}
+	}
+	return 0, 0, ErrInvalidTag
+}
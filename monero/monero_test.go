@@ -0,0 +1,211 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package monero
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Addr string
+	Net  Network
+	Type Type
+}
+
+//
+// Variables
+//
+
+// Keys of the Monero General Fund address
+const (
+	testSpendKey  = "42f18fc61586554095b0799b5c4b6f00cdeb26a93b20540d366932c6001617b7"
+	testViewKey   = "5db35109fbba7d5f275fef4b9c49e0cc1c84b219ec6ff652fda54f89f7f63c88"
+	testPaymentID = "0123456789abcdef"
+)
+
+// Valid addresses
+var testVectValid = []testVectEntry {
+	// Monero General Fund
+	testVectEntry { "44AFFq5kSiGBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGQBEP3A", Mainnet, Standard },
+	testVectEntry { "4DrvGduF3ynBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVPkQywN5o4wNU3C4oBH", Mainnet, Integrated },
+	testVectEntry { "84zPbCjb38gBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGMwZRBo", Mainnet, Subaddress },
+	testVectEntry { "9uhnk5k1j5NBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGRySiok", Testnet, Standard },
+	testVectEntry { "A5QTktZWLLtBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVPkQywN5o4wNTyr4pY1", Testnet, Integrated },
+	testVectEntry { "BaiWt9vwokYBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGPcPUPH", Testnet, Subaddress },
+	testVectEntry { "54NHLfzi6KNBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGRtzGna", Stagenet, Standard },
+	testVectEntry { "5E4xMUpChatBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVPkQywN5o4wNU3H4L1L", Stagenet, Integrated },
+	testVectEntry { "74nMWMpdPXaBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGS4dVHw", Stagenet, Subaddress },
+}
+
+//
+// Functions
+//
+
+// Test valid addresses
+func TestValid(t *testing.T) {
+	spendKey, _ := hex.DecodeString(testSpendKey)
+	viewKey, _ := hex.DecodeString(testViewKey)
+
+	for _, currTest := range testVectValid {
+		var paymentID []byte
+		if currTest.Type == Integrated {
+			paymentID, _ = hex.DecodeString(testPaymentID)
+		}
+
+		// Decode
+		addr, err := Decode(currTest.Addr)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if addr.Net != currTest.Net || addr.Type != currTest.Type ||
+		   hex.EncodeToString(addr.SpendKey[:]) != testSpendKey || hex.EncodeToString(addr.ViewKey[:]) != testViewKey ||
+		   (currTest.Type == Integrated && hex.EncodeToString(addr.PaymentID[:]) != testPaymentID) {
+			t.Errorf("Decoding (%s) was incorrect: got %v", currTest.Addr, addr)
+		}
+
+		// Encode
+		addr, err = New(currTest.Net, currTest.Type, spendKey, viewKey, paymentID)
+		if err != nil {
+			t.Errorf("Creating address (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if addr.String() != currTest.Addr {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Addr, addr.String())
+		}
+	}
+}
+
+// Test conversion between standard and integrated addresses
+func TestIntegrated(t *testing.T) {
+	paymentID, _ := hex.DecodeString(testPaymentID)
+
+	for i := 0; i < len(testVectValid); i += 3 {
+		std, _ := Decode(testVectValid[i].Addr)
+		integrated, err := std.Integrated(paymentID)
+		if err != nil || integrated.String() != testVectValid[i + 1].Addr {
+			t.Errorf("Converting (%s) to integrated address was incorrect", testVectValid[i].Addr)
+			continue
+		}
+		std, err = integrated.Standard()
+		if err != nil || std.String() != testVectValid[i].Addr {
+			t.Errorf("Converting (%s) to standard address was incorrect", testVectValid[i + 1].Addr)
+		}
+
+		// Subaddresses cannot be converted
+		sub, _ := Decode(testVectValid[i + 2].Addr)
+		if _, err = sub.Integrated(paymentID); err != ErrInvalidType {
+			t.Errorf("Converting subaddress to integrated address returned wrong error")
+		}
+		if _, err = sub.Standard(); err != ErrInvalidType {
+			t.Errorf("Converting subaddress to standard address returned wrong error")
+		}
+	}
+}
+
+// Test invalid addresses
+func TestInvalid(t *testing.T) {
+	spendKey, _ := hex.DecodeString(testSpendKey)
+	viewKey, _ := hex.DecodeString(testViewKey)
+	paymentID, _ := hex.DecodeString(testPaymentID)
+
+	// Encode the specified payload with the block encoding, appending the checksum
+	encode := func(data ...[]byte) string {
+		var payload []byte
+		for _, currData := range data {
+			payload = append(payload, currData...)
+		}
+		payload = append(payload, base58.ChecksumKeccak256.Compute(payload)...)
+		return base58.New(base58.AlphabetBitcoin).BlockEncode(payload)
+	}
+
+	testVectInvalid := []struct {
+		Addr string
+		Err  error
+	} {
+		// Empty
+		{ "", ErrInvalidTag },
+		// Invalid checksum
+		{ "44AFFq5kSiGBoZ4NMDwYtN18obc8AemS33DBLWs3H7otXft3XjrpDtQGv7SqSsaBYBb98uNbr2VBBEt7f2wfn3RVGQBEP3B", base58.ErrInvalidChecksum },
+		// Unknown tag
+		{ encode([]byte { 17 }, spendKey, viewKey), ErrInvalidTag },
+		// Not minimally encoded tag
+		{ encode([]byte { 0x92, 0x00 }, spendKey, viewKey), ErrInvalidTag },
+		// Invalid lengths
+		{ encode([]byte { 18 }, spendKey, viewKey[1:]), ErrInvalidLength },
+		{ encode([]byte { 18 }, spendKey, viewKey, paymentID), ErrInvalidLength },
+		{ encode([]byte { 19 }, spendKey, viewKey), ErrInvalidLength },
+		{ encode([]byte { 42 }, spendKey, viewKey, []byte { 0x00 }), ErrInvalidLength },
+		// Invalid block length
+		{ testVectValid[0].Addr[:89], base58.ErrInvalidBlockLen },
+	}
+	for _, currTest := range testVectInvalid {
+		if _, err := Decode(currTest.Addr); err != currTest.Err {
+			t.Errorf("Decoding invalid address (%s) returned wrong error: %v", currTest.Addr, err)
+		}
+	}
+	if _, err := Decode("0" + testVectValid[0].Addr[1:]); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding address with invalid character returned wrong error")
+	}
+
+	// Invalid parameters
+	if _, err := New(Network(3), Standard, spendKey, viewKey, nil); err != ErrInvalidNetwork {
+		t.Errorf("Creating address with invalid network returned wrong error")
+	}
+	if _, err := New(Mainnet, Type(3), spendKey, viewKey, nil); err != ErrInvalidType {
+		t.Errorf("Creating address with invalid type returned wrong error")
+	}
+	if _, err := New(Mainnet, Standard, spendKey[1:], viewKey, nil); err != ErrInvalidLength {
+		t.Errorf("Creating address with short spend key returned wrong error")
+	}
+	if _, err := New(Mainnet, Standard, spendKey, viewKey, paymentID); err != ErrInvalidPaymentID {
+		t.Errorf("Creating standard address with payment ID returned wrong error")
+	}
+	if _, err := New(Mainnet, Integrated, spendKey, viewKey, nil); err != ErrInvalidPaymentID {
+		t.Errorf("Creating integrated address without payment ID returned wrong error")
+	}
+	if _, err := New(Mainnet, Integrated, spendKey, viewKey, paymentID[1:]); err != ErrInvalidPaymentID {
+		t.Errorf("Creating integrated address with short payment ID returned wrong error")
+	}
+
+	// Invalid fields
+	if enc, err := (&Address { Net: Network(3) }).Encode(); enc != "" || err != ErrInvalidNetwork {
+		t.Errorf("Encoding address with invalid network returned wrong result")
+	}
+	if enc, err := (&Address { Type: Type(3) }).Encode(); enc != "" || err != ErrInvalidType {
+		t.Errorf("Encoding address with invalid type returned wrong result")
+	}
+	if (&Address { Net: Network(3) }).String() != "" {
+		t.Errorf("Getting string of address with invalid network was not empty")
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the multihash and IPFS CID encoding, built on top of base58btc.
+//
+
+// Package multihash implements multihashes and IPFS CIDs (v0 and v1), encoded in base58btc or base32.
+package multihash
+
+//
+// Imports
+//
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Identity hash code
+	Identity uint64 = 0x00
+	// SHA1 hash code
+	Sha1 uint64 = 0x11
+	// SHA2-256 hash code
+	Sha2_256 uint64 = 0x12
+	// SHA2-512 hash code
+	Sha2_512 uint64 = 0x13
+
+	// Raw binary codec
+	Raw uint64 = 0x55
+	// MerkleDAG protobuf codec (the only one allowed by CIDv0)
+	DagPb uint64 = 0x70
+
+	// Multibase prefix for base58btc
+	MultibaseBase58Btc = 'z'
+	// Multibase prefix for base32 (lowercase, no padding)
+	MultibaseBase32 = 'b'
+
+	// Maximum varint length, as defined by the multiformats unsigned varint specification
+	maxVarintLen = 9
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidVarint is returned when a varint is not valid or not minimally encoded
+	ErrInvalidVarint = errors.New("The varint is not valid")
+	// ErrInvalidLength is returned when the digest length does not match the declared or the expected one
+	ErrInvalidLength = errors.New("The digest length is not valid")
+	// ErrInvalidCID is returned when the CID is not valid
+	ErrInvalidCID = errors.New("The CID is not valid")
+	// ErrInvalidMultibase is returned when the multibase prefix is not supported
+	ErrInvalidMultibase = errors.New("The multibase prefix is not supported")
+	// ErrNotCIDv0 is returned when converting to CIDv0 a CID that cannot be represented as such
+	ErrNotCIDv0 = errors.New("The CID cannot be converted to CIDv0")
+
+	// Map from hash code to digest length, for known hashes (identity has variable length)
+	digestLenMap = map[uint64]int {
+		Sha1:     20,
+		Sha2_256: 32,
+		Sha2_512: 64,
+	}
+
+	// Base32 encoding
+	base32Enc = base32.StdEncoding.WithPadding(base32.NoPadding)
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Multihash structure
+type Multihash struct {
+	Code   uint64
+	Digest []byte
+}
+
+// CID structure
+type CID struct {
+	Version uint64
+	Codec   uint64
+	Hash    *Multihash
+}
+
+//
+// Exported functions
+//
+
+// Create a new multihash from the specified hash code and digest.
+func New(code uint64, digest []byte) (*Multihash, error) {
+	if expLen, ok := digestLenMap[code]; ok && len(digest) != expLen {
+		return nil, ErrInvalidLength
+	}
+
+	return &Multihash {
+		Code:   code,
+		Digest: append([]byte {}, digest...),
+	}, nil
+}
+
+// Decode the specified base58btc multihash string.
+func Decode(mhStr string) (*Multihash, error) {
+	dec, err := base58Btc.Decode(mhStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(dec)
+}
+
+// Parse the specified multihash bytes.
+func Parse(data []byte) (*Multihash, error) {
+	mh, n, err := parseMultihash(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(data) {
+		return nil, ErrInvalidLength
+	}
+
+	return mh, nil
+}
+
+// Get the multihash bytes.
+func (mh *Multihash) Bytes() []byte {
+	data := make([]byte, 0, 2 * maxVarintLen + len(mh.Digest))
+	data = AppendVarint(data, mh.Code)
+	data = AppendVarint(data, uint64(len(mh.Digest)))
+	return append(data, mh.Digest...)
+}
+
+// Get the base58btc multihash string.
+func (mh *Multihash) String() string {
+	return base58Btc.Encode(mh.Bytes())
+}
+
+// Create a new CIDv0 from the specified multihash, that shall be SHA2-256.
+func NewCIDv0(mh *Multihash) (*CID, error) {
+	if mh.Code != Sha2_256 {
+		return nil, ErrNotCIDv0
+	}
+
+	return &CID {
+		Version: 0,
+		Codec:   DagPb,
+		Hash:    mh,
+	}, nil
+}
+
+// Create a new CIDv1 from the specified codec and multihash.
+func NewCIDv1(codec uint64, mh *Multihash) *CID {
+	return &CID {
+		Version: 1,
+		Codec:   codec,
+		Hash:    mh,
+	}
+}
+
+// Decode the specified CID string.
+// CIDv0 strings are plain base58btc multihashes ("Qm..."), while CIDv1 strings are multibase-prefixed
+// ('z' for base58btc, 'b' for base32).
+func DecodeCID(cidStr string) (*CID, error) {
+	// CIDv0
+	if len(cidStr) == 46 && strings.HasPrefix(cidStr, "Qm") {
+		mh, err := Decode(cidStr)
+		if err != nil {
+			return nil, err
+		}
+		return NewCIDv0(mh)
+	}
+
+	// CIDv1
+	if len(cidStr) == 0 {
+		return nil, ErrInvalidCID
+	}
+
+	var dec []byte
+	var err error
+	switch cidStr[0] {
+	case MultibaseBase58Btc:
+		dec, err = base58Btc.Decode(cidStr[1:])
+	case MultibaseBase32:
+		dec, err = base32Enc.DecodeString(strings.ToUpper(cidStr[1:]))
+	default:
+		return nil, ErrInvalidMultibase
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCID(dec)
+}
+
+// Parse the specified CIDv1 bytes.
+func ParseCID(data []byte) (*CID, error) {
+	version, n, err := ReadVarint(data)
+	if err != nil {
+		return nil, err
+	}
+	if version != 1 {
+		return nil, ErrInvalidCID
+	}
+	data = data[n:]
+
+	codec, n, err := ReadVarint(data)
+	if err != nil {
+		return nil, err
+	}
+
+	mh, err := Parse(data[n:])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCIDv1(codec, mh), nil
+}
+
+// Get the CID bytes.
+// For CIDv0, they are the multihash bytes.
+func (cid *CID) Bytes() []byte {
+	if cid.Version == 0 {
+		return cid.Hash.Bytes()
+	}
+
+	data := make([]byte, 0, 2 * maxVarintLen)
+	data = AppendVarint(data, cid.Version)
+	data = AppendVarint(data, cid.Codec)
+	return append(data, cid.Hash.Bytes()...)
+}
+
+// Get the CID string, in base58btc for CIDv0 and in multibase base32 for CIDv1.
+func (cid *CID) String() string {
+	if cid.Version == 0 {
+		return cid.Hash.String()
+	}
+	return string(MultibaseBase32) + strings.ToLower(base32Enc.EncodeToString(cid.Bytes()))
+}
+
+// Get the CID string in multibase base58btc ('z').
+// For CIDv0, it is the same of String.
+func (cid *CID) StringBase58() string {
+	if cid.Version == 0 {
+		return cid.Hash.String()
+	}
+	return string(MultibaseBase58Btc) + base58Btc.Encode(cid.Bytes())
+}
+
+// Get the CIDv1 equivalent of the CID.
+func (cid *CID) ToV1() *CID {
+	return NewCIDv1(cid.Codec, cid.Hash)
+}
+
+// Get the CIDv0 equivalent of the CID.
+// It returns ErrNotCIDv0 if the CID codec is not dag-pb or the hash is not SHA2-256.
+func (cid *CID) ToV0() (*CID, error) {
+	if cid.Codec != DagPb {
+		return nil, ErrNotCIDv0
+	}
+	return NewCIDv0(cid.Hash)
+}
+
+// Read an unsigned varint from the beginning of the specified bytes, returning also the number of read bytes.
+// The varint shall be minimally encoded and not longer than 9 bytes, as defined by the multiformats specification.
+func ReadVarint(data []byte) (uint64, int, error) {
+	if len(data) > maxVarintLen {
+		data = data[:maxVarintLen]
+	}
+
+	val, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, 0, ErrInvalidVarint
+	}
+	// Last byte shall not be zero, unless it is the only one
+	if n > 1 && data[n - 1] == 0 {
+		return 0, 0, ErrInvalidVarint
+	}
+
+	return val, n, nil
+}
+
+// Append the specified value as unsigned varint and return the extended slice.
+func AppendVarint(data []byte, val uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], val)
+	return append(data, buf[:n]...)
+}
+
+//
+// Not-exported functions
+//
+
+// Parse a multihash from the beginning of the specified bytes, returning the number of read bytes
+func parseMultihash(data []byte) (*Multihash, int, error) {
+	code, n1, err := ReadVarint(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	digestLen, n2, err := ReadVarint(data[n1:])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Check declared length
+	n := n1 + n2
+	if digestLen > uint64(len(data) - n) {
+		return nil, 0, ErrInvalidLength
+	}
+
+	mh, err := New(code, data[n:n + int(digestLen)])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return mh, n + int(digestLen), nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package multihash
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure for CIDs
+type testVectCIDEntry struct {
+	CIDv0       string
+	CIDv1Base32 string
+	CIDv1Base58 string
+	Digest      string
+}
+
+//
+// Variables
+//
+
+// CIDs
+var testVectCID = []testVectCIDEntry {
+	testVectCIDEntry {
+		CIDv0:       "QmdfTbBqBPQ7VNxZEYEj14VmRuZBkqFbiwReogJgS1zR1n",
+		CIDv1Base32: "bafybeihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku",
+		CIDv1Base58: "zdj7Wkkhxcu2rsiN6GUyHCLsSLL47kdUNfjbFqBUUhMFTZKBi",
+		Digest:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	},
+	// Empty directory
+	testVectCIDEntry {
+		CIDv0:       "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn",
+		CIDv1Base32: "bafybeiczsscdsbs7ffqz55asqdf3smv6klcw3gofszvwlyarci47bgf354",
+		CIDv1Base58: "zdj7WbTaiJT1fgatdet9Ei9iDB5hdCxkbVyhyh8YTUnXMiwYi",
+		Digest:      "59948439065f29619ef41280cbb932be52c56d99c5966b65e0111239f098bbef",
+	},
+}
+
+// Multihashes
+var testVectMultihash = []struct {
+	Code   uint64
+	Digest string
+	Bytes  string
+} {
+	{ Identity, "", "0000" },
+	{ Identity, "68656c6c6f", "000568656c6c6f" },
+	{ Sha1, "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12", "11142fd4e1c67a2d28fced849ee1bb76e7391b93eb12" },
+	{ Sha2_256, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "1220e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" },
+	// Unknown code (2-byte varint)
+	{ 0xb220, "0102", "a0e4020201" + "02" },
+}
+
+// Invalid multihashes bytes
+var testVectInvalidMultihash = []struct {
+	Bytes string
+	Err   error
+} {
+	// Empty and truncated varints
+	{ "", ErrInvalidVarint },
+	{ "12", ErrInvalidVarint },
+	{ "80", ErrInvalidVarint },
+	// Varint longer than 9 bytes
+	{ "80808080808080808001" + "00", ErrInvalidVarint },
+	// Not minimally encoded varint
+	{ "920020" + "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ErrInvalidVarint },
+	// Digest shorter than declared
+	{ "0005686565", ErrInvalidLength },
+	// Digest longer than declared
+	{ "000168656c", ErrInvalidLength },
+	// Wrong digest length for SHA2-256
+	{ "1204e3b0c442", ErrInvalidLength },
+}
+
+//
+// Functions
+//
+
+// Test multihashes
+func TestMultihash(t *testing.T) {
+	for _, currTest := range testVectMultihash {
+		digest, _ := hex.DecodeString(currTest.Digest)
+		data, _ := hex.DecodeString(currTest.Bytes)
+
+		mh, err := New(currTest.Code, digest)
+		if err != nil {
+			t.Errorf("Creating multihash (%s) returned error: %s", currTest.Bytes, err.Error())
+			continue
+		}
+		if !bytes.Equal(mh.Bytes(), data) {
+			t.Errorf("Encoding was incorrect: expected %s, got: %x", currTest.Bytes, mh.Bytes())
+		}
+
+		// Decode from base58
+		mh, err = Decode(mh.String())
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Bytes, err.Error())
+			continue
+		}
+		if mh.Code != currTest.Code || !bytes.Equal(mh.Digest, digest) {
+			t.Errorf("Decoding (%s) was incorrect: got %x %x", currTest.Bytes, mh.Code, mh.Digest)
+		}
+	}
+}
+
+// Test invalid multihashes
+func TestInvalidMultihash(t *testing.T) {
+	for _, currTest := range testVectInvalidMultihash {
+		data, _ := hex.DecodeString(currTest.Bytes)
+		if _, err := Parse(data); err != currTest.Err {
+			t.Errorf("Parsing invalid multihash (%s) returned wrong error: %v", currTest.Bytes, err)
+		}
+		if _, err := Decode(base58Btc.Encode(data)); err != currTest.Err {
+			t.Errorf("Decoding invalid multihash (%s) returned wrong error: %v", currTest.Bytes, err)
+		}
+	}
+
+	if _, err := New(Sha2_256, make([]byte, 31)); err != ErrInvalidLength {
+		t.Errorf("Creating SHA2-256 multihash with wrong length returned wrong error")
+	}
+	if _, err := Decode("0"); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding multihash with invalid character returned wrong error")
+	}
+}
+
+// Test CIDs
+func TestCID(t *testing.T) {
+	for _, currTest := range testVectCID {
+		// CIDv0
+		cidV0, err := DecodeCID(currTest.CIDv0)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.CIDv0, err.Error())
+			continue
+		}
+		if cidV0.Version != 0 || cidV0.Codec != DagPb || cidV0.Hash.Code != Sha2_256 || hex.EncodeToString(cidV0.Hash.Digest) != currTest.Digest {
+			t.Errorf("Decoding (%s) was incorrect", currTest.CIDv0)
+		}
+		if cidV0.String() != currTest.CIDv0 || cidV0.StringBase58() != currTest.CIDv0 {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.CIDv0, cidV0.String())
+		}
+
+		// CIDv0 -> CIDv1
+		cidV1 := cidV0.ToV1()
+		if cidV1.String() != currTest.CIDv1Base32 {
+			t.Errorf("Converting to CIDv1 was incorrect: expected %s, got: %s", currTest.CIDv1Base32, cidV1.String())
+		}
+		if cidV1.StringBase58() != currTest.CIDv1Base58 {
+			t.Errorf("Converting to CIDv1 was incorrect: expected %s, got: %s", currTest.CIDv1Base58, cidV1.StringBase58())
+		}
+
+		// CIDv1 -> CIDv0
+		for _, cidStr := range []string { currTest.CIDv1Base32, currTest.CIDv1Base58 } {
+			cidV1, err = DecodeCID(cidStr)
+			if err != nil {
+				t.Errorf("Decoding (%s) returned error: %s", cidStr, err.Error())
+				continue
+			}
+			if cidV1.Version != 1 || cidV1.Codec != DagPb || hex.EncodeToString(cidV1.Hash.Digest) != currTest.Digest {
+				t.Errorf("Decoding (%s) was incorrect", cidStr)
+			}
+			cidV0, err = cidV1.ToV0()
+			if err != nil || cidV0.String() != currTest.CIDv0 {
+				t.Errorf("Converting (%s) to CIDv0 was incorrect", cidStr)
+			}
+		}
+	}
+}
+
+// Test invalid CIDs
+func TestInvalidCID(t *testing.T) {
+	digest, _ := hex.DecodeString(testVectCID[0].Digest)
+	mh, _ := New(Sha2_256, digest)
+
+	// Raw codec and identity hash cannot be CIDv0
+	if _, err := NewCIDv1(Raw, mh).ToV0(); err != ErrNotCIDv0 {
+		t.Errorf("Converting raw CID to CIDv0 returned wrong error")
+	}
+	mhId, _ := New(Identity, []byte("hello"))
+	if _, err := NewCIDv1(DagPb, mhId).ToV0(); err != ErrNotCIDv0 {
+		t.Errorf("Converting identity CID to CIDv0 returned wrong error")
+	}
+
+	// Raw codec round trip
+	cidRaw := NewCIDv1(Raw, mh)
+	if cid, err := DecodeCID(cidRaw.String()); err != nil || cid.Codec != Raw || !bytes.Equal(cid.Bytes(), cidRaw.Bytes()) {
+		t.Errorf("Decoding raw CID was incorrect")
+	}
+
+	invalidCIDs := []struct {
+		CID string
+		Err error
+	} {
+		{ "", ErrInvalidCID },
+		// Unsupported multibase
+		{ "f01701220" + testVectCID[0].Digest, ErrInvalidMultibase },
+		// Wrong version (CIDv0 bytes in multibase)
+		{ "z" + testVectCID[0].CIDv0, ErrInvalidCID },
+		{ "z" + base58Btc.Encode(append([]byte { 0x02, 0x70 }, mh.Bytes()...)), ErrInvalidCID },
+		// Truncated multihash
+		{ "z" + base58Btc.Encode(append([]byte { 0x01, 0x70 }, mh.Bytes()[:10]...)), ErrInvalidLength },
+		// Trailing bytes
+		{ "z" + base58Btc.Encode(append(NewCIDv1(DagPb, mh).Bytes(), 0x00)), ErrInvalidLength },
+	}
+	for _, currTest := range invalidCIDs {
+		if _, err := DecodeCID(currTest.CID); err != currTest.Err {
+			t.Errorf("Decoding invalid CID (%s) returned wrong error: %v", currTest.CID, err)
+		}
+	}
+
+	// Invalid characters
+	if _, err := DecodeCID("b" + "0189"); err == nil {
+		t.Errorf("Decoding CID with invalid base32 characters returned no error")
+	}
+	if _, err := DecodeCID("QmdfTbBqBPQ7VNxZEYEj14VmRuZBkqFbiwReogJgS1zR10"); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding CIDv0 with invalid characters returned wrong error")
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the did:key identifiers and libp2p peer IDs, built on top of base58btc.
+//
+
+// Package multikey implements did:key identifiers and libp2p peer IDs for ed25519 and secp256k1 public keys.
+package multikey
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/ebellocchia/go-base58"
+	"github.com/ebellocchia/go-base58/multihash"
+)
+
+//
+// Constants
+//
+const (
+	// ed25519 key type
+	Ed25519 KeyType = 0
+	// secp256k1 key type
+	Secp256k1 KeyType = 1
+
+	// did:key prefix
+	DIDKeyPrefix = "did:key:"
+
+	// ed25519 public key length
+	Ed25519KeyLen = ed25519.PublicKeySize
+	// secp256k1 compressed public key length
+	Secp256k1KeyLen = 33
+
+	// Maximum length of a public key that is inlined in the peer ID with the identity multihash
+	maxInlineKeyLen = 42
+
+	// Protobuf tag of the key type field (field 1, varint)
+	protoTypeTag = 0x08
+	// Protobuf tag of the key data field (field 2, length-delimited)
+	protoDataTag = 0x12
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidKeyType is returned when using a not-existent key type
+	ErrInvalidKeyType = errors.New("The specified key type is not existent")
+	// ErrInvalidKey is returned when the public key is not valid
+	ErrInvalidKey = errors.New("The public key is not valid")
+	// ErrInvalidDIDKey is returned when the did:key identifier is not valid
+	ErrInvalidDIDKey = errors.New("The did:key identifier is not valid")
+	// ErrInvalidMulticodec is returned when the multicodec is not valid or not supported
+	ErrInvalidMulticodec = errors.New("The multicodec is not valid or not supported")
+	// ErrInvalidPeerID is returned when the peer ID is not valid
+	ErrInvalidPeerID = errors.New("The peer ID is not valid")
+	// ErrNoPublicKey is returned when getting the public key of a peer ID that does not inline it
+	ErrNoPublicKey = errors.New("The peer ID does not contain the public key")
+
+	// Map from key type to key information
+	keyInfoMap = map[KeyType]keyInfo {
+		Ed25519:   keyInfo { Multicodec: 0xed, ProtoType: 1, Len: Ed25519KeyLen },
+		Secp256k1: keyInfo { Multicodec: 0xe7, ProtoType: 2, Len: Secp256k1KeyLen },
+	}
+)
+
+//
+// Types
+//
+
+// Key type
+type KeyType int
+
+// Public key structure
+type PublicKey struct {
+	Type KeyType
+	Data []byte
+}
+
+// Peer ID structure.
+// The multihash is identity for inlined public keys (e.g. "12D3KooW..."), sha2-256 otherwise (e.g. "Qm...").
+type PeerID struct {
+	Hash *multihash.Multihash
+}
+
+// Key information structure
+type keyInfo struct {
+	// Multicodec code (used by did:key)
+	Multicodec uint64
+	// Protobuf key type (used by libp2p)
+	ProtoType  uint64
+	// Public key length
+	Len        int
+}
+
+//
+// Exported functions
+//
+
+// Create a new public key from the specified key type and data.
+// secp256k1 public keys shall be compressed.
+func NewPublicKey(keyType KeyType, data []byte) (*PublicKey, error) {
+	info, ok := keyInfoMap[keyType]
+	if !ok {
+		return nil, ErrInvalidKeyType
+	}
+	if len(data) != info.Len {
+		return nil, ErrInvalidKey
+	}
+	if keyType == Secp256k1 && data[0] != 0x02 && data[0] != 0x03 {
+		return nil, ErrInvalidKey
+	}
+
+	return &PublicKey {
+		Type: keyType,
+		Data: append([]byte {}, data...),
+	}, nil
+}
+
+// Create a new public key from the specified ed25519 public key.
+func FromEd25519(pubKey ed25519.PublicKey) (*PublicKey, error) {
+	return NewPublicKey(Ed25519, pubKey)
+}
+
+// Get the ed25519 public key.
+// It returns ErrInvalidKeyType if the public key is not ed25519.
+func (pubKey *PublicKey) Ed25519() (ed25519.PublicKey, error) {
+	if pubKey.Type != Ed25519 {
+		return nil, ErrInvalidKeyType
+	}
+	return append(ed25519.PublicKey {}, pubKey.Data...), nil
+}
+
+// Decode the specified did:key identifier ("did:key:z...").
+func DecodeDIDKey(did string) (*PublicKey, error) {
+	if !strings.HasPrefix(did, DIDKeyPrefix) {
+		return nil, ErrInvalidDIDKey
+	}
+
+	alphIdx, dec, err := base58.MultibaseDecode(did[len(DIDKeyPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	if alphIdx != base58.AlphabetBitcoin {
+		return nil, ErrInvalidDIDKey
+	}
+
+	// Get key type from multicodec
+	codec, n, err := multihash.ReadVarint(dec)
+	if err != nil {
+		return nil, ErrInvalidMulticodec
+	}
+	for keyType, info := range keyInfoMap {
+		if info.Multicodec == codec {
+			return NewPublicKey(keyType, dec[n:])
+		}
+	}
+	return nil, ErrInvalidMulticodec
+}
+
+// Get the did:key identifier ("did:key:z...").
+func (pubKey *PublicKey) DIDKey() string {
+	data := multihash.AppendVarint(nil, keyInfoMap[pubKey.Type].Multicodec)
+	data = append(data, pubKey.Data...)
+
+	enc, _ := base58.MultibaseEncode(base58.AlphabetBitcoin, data)
+	return DIDKeyPrefix + enc
+}
+
+// Get the peer ID.
+// The public key is inlined with the identity multihash if not longer than 42 bytes (always the case
+// for ed25519 and secp256k1), otherwise the sha2-256 multihash is used.
+func (pubKey *PublicKey) PeerID() *PeerID {
+	protoKey := pubKey.marshalProto()
+
+	var mh *multihash.Multihash
+	if len(protoKey) <= maxInlineKeyLen {
+		mh, _ = multihash.New(multihash.Identity, protoKey)
+	} else {
+		digest := sha256.Sum256(protoKey)
+		mh, _ = multihash.New(multihash.Sha2_256, digest[:])
+	}
+
+	return &PeerID { Hash: mh }
+}
+
+// Decode the specified peer ID ("12D3KooW...", "16Uiu2...", "Qm...").
+func DecodePeerID(peerIDStr string) (*PeerID, error) {
+	mh, err := multihash.Decode(peerIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	peerID := &PeerID { Hash: mh }
+	switch mh.Code {
+	case multihash.Identity:
+		// Inlined public key shall be valid and short enough
+		if len(mh.Digest) > maxInlineKeyLen {
+			return nil, ErrInvalidPeerID
+		}
+		if _, err := peerID.PublicKey(); err != nil {
+			return nil, err
+		}
+	case multihash.Sha2_256:
+	default:
+		return nil, ErrInvalidPeerID
+	}
+
+	return peerID, nil
+}
+
+// Get the public key inlined in the peer ID.
+// It returns ErrNoPublicKey if the peer ID is a sha2-256 multihash.
+func (peerID *PeerID) PublicKey() (*PublicKey, error) {
+	if peerID.Hash.Code != multihash.Identity {
+		return nil, ErrNoPublicKey
+	}
+	return unmarshalProto(peerID.Hash.Digest)
+}
+
+// Get if the peer ID matches the specified public key.
+// Both the identity and the (legacy) sha2-256 forms are matched.
+func (peerID *PeerID) Matches(pubKey *PublicKey) bool {
+	protoKey := pubKey.marshalProto()
+
+	switch peerID.Hash.Code {
+	case multihash.Identity:
+		return bytes.Equal(peerID.Hash.Digest, protoKey)
+	case multihash.Sha2_256:
+		digest := sha256.Sum256(protoKey)
+		return bytes.Equal(peerID.Hash.Digest, digest[:])
+	default:
+		return false
+	}
+}
+
+// Get the peer ID string.
+func (peerID *PeerID) String() string {
+	return peerID.Hash.String()
+}
+
+//
+// Not-exported functions
+//
+
+// Marshal the public key to the libp2p protobuf format (type and data fields)
+func (pubKey *PublicKey) marshalProto() []byte {
+	data := make([]byte, 0, 2 * binary.MaxVarintLen64 + 2 + len(pubKey.Data))
+	data = append(data, protoTypeTag)
+	data = multihash.AppendVarint(data, keyInfoMap[pubKey.Type].ProtoType)
+	data = append(data, protoDataTag)
+	data = multihash.AppendVarint(data, uint64(len(pubKey.Data)))
+	return append(data, pubKey.Data...)
+}
+
+// Unmarshal the public key from the libp2p protobuf format (type and data fields)
+func unmarshalProto(data []byte) (*PublicKey, error) {
+	if len(data) == 0 || data[0] != protoTypeTag {
+		return nil, ErrInvalidPeerID
+	}
+	protoType, n, err := multihash.ReadVarint(data[1:])
+	if err != nil {
+		return nil, ErrInvalidPeerID
+	}
+	data = data[1 + n:]
+
+	if len(data) == 0 || data[0] != protoDataTag {
+		return nil, ErrInvalidPeerID
+	}
+	keyLen, n, err := multihash.ReadVarint(data[1:])
+	if err != nil {
+		return nil, ErrInvalidPeerID
+	}
+	data = data[1 + n:]
+	if keyLen != uint64(len(data)) {
+		return nil, ErrInvalidPeerID
+	}
+
+	for keyType, info := range keyInfoMap {
+		if info.ProtoType == protoType {
+			return NewPublicKey(keyType, data)
+		}
+	}
+	return nil, ErrInvalidKeyType
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package multikey
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+	"github.com/ebellocchia/go-base58/multihash"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Type   KeyType
+	Key    string
+	DIDKey string
+	PeerID string
+}
+
+//
+// Variables
+//
+
+// Public keys
+var testVectKey = []testVectEntry {
+	// From the did:key specification, same key of a libp2p bootstrap peer
+	testVectEntry {
+		Type:   Ed25519,
+		Key:    "3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29",
+		DIDKey: "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp",
+		PeerID: "12D3KooWDpJ7As7BWAwRMfu1VU2WCqNjvq387JEYKDBj4kx6nXTN",
+	},
+	// RFC 8032 test 1
+	testVectEntry {
+		Type:   Ed25519,
+		Key:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		DIDKey: "did:key:z6MktwupdmLXVVqTzCw4i46r4uGyosGXRnR3XjN4Zq7oMMsw",
+		PeerID: "12D3KooWQK1wnefoLrcVHbbnf5tLzbopUd3K3bFAoJpA7YJgL5pV",
+	},
+	// secp256k1 generator point
+	testVectEntry {
+		Type:   Secp256k1,
+		Key:    "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		DIDKey: "did:key:zQ3shVc2UkAfJCdc1TR8E66J85h48P43r93q8jGPkPpjF9Ef9",
+		PeerID: "16Uiu2HAm3cuhhRL2msUuLF62KRSfneFDx94RsuouyW25Ho42cFMq",
+	},
+}
+
+// Peer ID with sha2-256 multihash (RSA key of a libp2p bootstrap peer)
+var testPeerIDSha256 = "QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N"
+
+// Legacy peer ID with sha2-256 multihash of an ed25519 key (RFC 8032 test 1)
+var testPeerIDSha256Ed25519 = "QmVaqf5ic3srV8kfoxuFpyBmXW8EHUFCJapxWbB4b2upyw"
+
+//
+// Functions
+//
+
+// Test did:key identifiers
+func TestDIDKey(t *testing.T) {
+	for _, currTest := range testVectKey {
+		key, _ := hex.DecodeString(currTest.Key)
+
+		// Encode
+		pubKey, err := NewPublicKey(currTest.Type, key)
+		if err != nil {
+			t.Errorf("Creating public key (%s) returned error: %s", currTest.Key, err.Error())
+			continue
+		}
+		if pubKey.DIDKey() != currTest.DIDKey {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.DIDKey, pubKey.DIDKey())
+		}
+
+		// Decode
+		pubKey, err = DecodeDIDKey(currTest.DIDKey)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.DIDKey, err.Error())
+			continue
+		}
+		if pubKey.Type != currTest.Type || !bytes.Equal(pubKey.Data, key) {
+			t.Errorf("Decoding (%s) was incorrect: got %d %x", currTest.DIDKey, pubKey.Type, pubKey.Data)
+		}
+	}
+}
+
+// Test peer IDs
+func TestPeerID(t *testing.T) {
+	for _, currTest := range testVectKey {
+		key, _ := hex.DecodeString(currTest.Key)
+
+		// Encode
+		pubKey, _ := NewPublicKey(currTest.Type, key)
+		if pubKey.PeerID().String() != currTest.PeerID {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.PeerID, pubKey.PeerID().String())
+		}
+
+		// Decode
+		peerID, err := DecodePeerID(currTest.PeerID)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.PeerID, err.Error())
+			continue
+		}
+		if peerID.Hash.Code != multihash.Identity || !peerID.Matches(pubKey) || peerID.String() != currTest.PeerID {
+			t.Errorf("Decoding (%s) was incorrect", currTest.PeerID)
+		}
+		pubKey, err = peerID.PublicKey()
+		if err != nil || pubKey.Type != currTest.Type || !bytes.Equal(pubKey.Data, key) {
+			t.Errorf("Getting public key of (%s) was incorrect", currTest.PeerID)
+		}
+	}
+
+	// sha2-256 peer ID does not contain the public key
+	peerID, err := DecodePeerID(testPeerIDSha256)
+	if err != nil {
+		t.Fatalf("Decoding (%s) returned error: %s", testPeerIDSha256, err.Error())
+	}
+	if peerID.Hash.Code != multihash.Sha2_256 || peerID.String() != testPeerIDSha256 {
+		t.Errorf("Decoding (%s) was incorrect", testPeerIDSha256)
+	}
+	if _, err = peerID.PublicKey(); err != ErrNoPublicKey {
+		t.Errorf("Getting public key of sha2-256 peer ID returned wrong error")
+	}
+	pubKey, _ := DecodeDIDKey(testVectKey[0].DIDKey)
+	if peerID.Matches(pubKey) {
+		t.Errorf("Peer ID matched a wrong public key")
+	}
+
+	// Legacy sha2-256 peer ID of an ed25519 key
+	peerID, err = DecodePeerID(testPeerIDSha256Ed25519)
+	if err != nil {
+		t.Fatalf("Decoding (%s) returned error: %s", testPeerIDSha256Ed25519, err.Error())
+	}
+	pubKey, _ = DecodeDIDKey(testVectKey[1].DIDKey)
+	if !peerID.Matches(pubKey) {
+		t.Errorf("Peer ID (%s) did not match its public key", testPeerIDSha256Ed25519)
+	}
+	pubKey, _ = DecodeDIDKey(testVectKey[0].DIDKey)
+	if peerID.Matches(pubKey) {
+		t.Errorf("Peer ID (%s) matched a wrong public key", testPeerIDSha256Ed25519)
+	}
+}
+
+// Test conversion from/to ed25519
+func TestEd25519(t *testing.T) {
+	key, _ := hex.DecodeString(testVectKey[0].Key)
+
+	pubKey, err := FromEd25519(key)
+	if err != nil {
+		t.Fatalf("Creating public key from ed25519 returned error: %s", err.Error())
+	}
+	edKey, err := pubKey.Ed25519()
+	if err != nil || !bytes.Equal(edKey, key) {
+		t.Errorf("Converting public key to ed25519 was incorrect")
+	}
+
+	pubKey, _ = DecodeDIDKey(testVectKey[2].DIDKey)
+	if _, err = pubKey.Ed25519(); err != ErrInvalidKeyType {
+		t.Errorf("Converting secp256k1 public key to ed25519 returned wrong error")
+	}
+}
+
+// Test invalid inputs
+func TestInvalid(t *testing.T) {
+	edKey, _ := hex.DecodeString(testVectKey[0].Key)
+	secpKey, _ := hex.DecodeString(testVectKey[2].Key)
+
+	// Invalid public keys
+	if _, err := NewPublicKey(KeyType(2), edKey); err != ErrInvalidKeyType {
+		t.Errorf("Creating public key with invalid type returned wrong error")
+	}
+	if _, err := NewPublicKey(Ed25519, secpKey); err != ErrInvalidKey {
+		t.Errorf("Creating ed25519 public key with wrong length returned wrong error")
+	}
+	if _, err := NewPublicKey(Secp256k1, append([]byte { 0x04 }, edKey...)); err != ErrInvalidKey {
+		t.Errorf("Creating uncompressed secp256k1 public key returned wrong error")
+	}
+
+	// Invalid did:key identifiers
+	multibase := func(data []byte) string {
+		enc, _ := base58.MultibaseEncode(base58.AlphabetBitcoin, data)
+		return DIDKeyPrefix + enc
+	}
+	testVectDIDKey := []struct {
+		DIDKey string
+		Err    error
+	} {
+		{ testVectKey[0].DIDKey[len(DIDKeyPrefix):], ErrInvalidDIDKey },
+		{ "did:web:" + testVectKey[0].DIDKey[len(DIDKeyPrefix):], ErrInvalidDIDKey },
+		{ "did:key:Z" + testVectKey[0].DIDKey[len(DIDKeyPrefix) + 1:], ErrInvalidDIDKey },
+		{ multibase(append([]byte { 0xec, 0x01 }, edKey...)), ErrInvalidMulticodec },
+		{ multibase(append([]byte { 0xed, 0x81, 0x00 }, edKey...)), ErrInvalidMulticodec },
+		{ multibase([]byte { 0xed }), ErrInvalidMulticodec },
+		{ multibase(append([]byte { 0xed, 0x81, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01 }, edKey...)), ErrInvalidMulticodec },
+		{ multibase(append([]byte { 0xed, 0x01 }, edKey[1:]...)), ErrInvalidKey },
+		{ multibase(append([]byte { 0xe7, 0x01 }, edKey...)), ErrInvalidKey },
+	}
+	for _, currTest := range testVectDIDKey {
+		if _, err := DecodeDIDKey(currTest.DIDKey); err != currTest.Err {
+			t.Errorf("Decoding invalid did:key (%s) returned wrong error: %v", currTest.DIDKey, err)
+		}
+	}
+	if _, err := DecodeDIDKey("did:key:f" + testVectKey[0].Key); !errors.Is(err, base58.ErrMultibasePrefix) {
+		t.Errorf("Decoding did:key with unknown multibase prefix returned wrong error: %v", err)
+	}
+
+	// Invalid peer IDs
+	identity := func(data []byte) string {
+		mh, _ := multihash.New(multihash.Identity, data)
+		return mh.String()
+	}
+	testVectPeerID := []struct {
+		PeerID string
+		Err    error
+	} {
+		// Not supported multihash
+		{ base58.New(base58.AlphabetBitcoin).Encode(append([]byte { 0x13, 0x40 }, make([]byte, 64)...)), ErrInvalidPeerID },
+		// Inlined key too long
+		{ identity(append([]byte { 0x08, 0x01, 0x12, 0x28 }, make([]byte, 40)...)), ErrInvalidPeerID },
+		// Invalid protobuf
+		{ identity([]byte {}), ErrInvalidPeerID },
+		{ identity(append([]byte { 0x10, 0x01, 0x12, 0x20 }, edKey...)), ErrInvalidPeerID },
+		{ identity(append([]byte { 0x08, 0x01, 0x1a, 0x20 }, edKey...)), ErrInvalidPeerID },
+		{ identity(append([]byte { 0x08, 0x01, 0x12, 0x21 }, edKey...)), ErrInvalidPeerID },
+		{ identity([]byte { 0x08, 0x81 }), ErrInvalidPeerID },
+		// Not supported key type (ECDSA)
+		{ identity(append([]byte { 0x08, 0x03, 0x12, 0x20 }, edKey...)), ErrInvalidKeyType },
+		// Wrong key length
+		{ identity(append([]byte { 0x08, 0x02, 0x12, 0x20 }, edKey...)), ErrInvalidKey },
+	}
+	for _, currTest := range testVectPeerID {
+		if _, err := DecodePeerID(currTest.PeerID); err != currTest.Err {
+			t.Errorf("Decoding invalid peer ID (%s) returned wrong error: %v", currTest.PeerID, err)
+		}
+	}
+}
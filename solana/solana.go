@@ -0,0 +1,264 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Solana public keys, signatures and keypairs, built on top of plain Base58.
+//
+
+// Package solana implements the Base58 encoding of Solana public keys, signatures and keypairs.
+package solana
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Public key length
+	PublicKeyLen = ed25519.PublicKeySize
+	// Signature length
+	SignatureLen = ed25519.SignatureSize
+	// Keypair length (seed followed by public key)
+	KeypairLen = ed25519.PrivateKeySize
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidLength is returned when the decoded length is not valid
+	ErrInvalidLength = errors.New("The decoded length is not valid")
+	// ErrInvalidKeypair is returned when the keypair public key does not match its seed
+	ErrInvalidKeypair = errors.New("The keypair public key does not match its seed")
+	// ErrInvalidJSON is returned when the JSON keypair is not an array of 64 bytes
+	ErrInvalidJSON = errors.New("The JSON keypair is not valid")
+
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Public key
+type PublicKey [PublicKeyLen]byte
+
+// Signature
+type Signature [SignatureLen]byte
+
+// Keypair (32-byte seed followed by 32-byte public key), as exported by wallets.
+// The keypair shall be zeroed with Zero when not needed anymore.
+type Keypair [KeypairLen]byte
+
+//
+// Exported functions
+//
+
+// Decode the specified public key string.
+func DecodePublicKey(pubKeyStr string) (PublicKey, error) {
+	var pubKey PublicKey
+	if err := decodeExact(pubKey[:], pubKeyStr); err != nil {
+		return PublicKey {}, err
+	}
+	return pubKey, nil
+}
+
+// Create a public key from the specified ed25519 public key.
+func PublicKeyFromEd25519(pubKey ed25519.PublicKey) (PublicKey, error) {
+	var solPubKey PublicKey
+	if len(pubKey) != PublicKeyLen {
+		return solPubKey, ErrInvalidLength
+	}
+	copy(solPubKey[:], pubKey)
+	return solPubKey, nil
+}
+
+// Get the ed25519 public key.
+func (pubKey PublicKey) Ed25519() ed25519.PublicKey {
+	return append(ed25519.PublicKey {}, pubKey[:]...)
+}
+
+// Get the public key string.
+func (pubKey PublicKey) String() string {
+	return base58Btc.Encode(pubKey[:])
+}
+
+// Decode the specified signature string.
+func DecodeSignature(sigStr string) (Signature, error) {
+	var sig Signature
+	if err := decodeExact(sig[:], sigStr); err != nil {
+		return Signature {}, err
+	}
+	return sig, nil
+}
+
+// Get the signature string.
+func (sig Signature) String() string {
+	return base58Btc.Encode(sig[:])
+}
+
+// Decode the specified keypair string, checking that the public key matches the seed.
+func DecodeKeypair(keypairStr string) (*Keypair, error) {
+	keypair := &Keypair {}
+	if err := decodeExact(keypair[:], keypairStr); err != nil {
+		keypair.Zero()
+		return nil, err
+	}
+	if err := keypair.validate(); err != nil {
+		keypair.Zero()
+		return nil, err
+	}
+	return keypair, nil
+}
+
+// Create a keypair from the specified ed25519 private key.
+func KeypairFromEd25519(privKey ed25519.PrivateKey) (*Keypair, error) {
+	if len(privKey) != KeypairLen {
+		return nil, ErrInvalidLength
+	}
+
+	keypair := &Keypair {}
+	copy(keypair[:], privKey)
+	if err := keypair.validate(); err != nil {
+		keypair.Zero()
+		return nil, err
+	}
+	return keypair, nil
+}
+
+// Create a keypair from the specified Solana CLI JSON keypair file content (an array of 64 integers).
+func KeypairFromJSON(data []byte) (*Keypair, error) {
+	var ints []int
+	if err := json.Unmarshal(data, &ints); err != nil {
+		return nil, ErrInvalidJSON
+	}
+	defer zeroInts(ints)
+
+	if len(ints) != KeypairLen {
+		return nil, ErrInvalidJSON
+	}
+
+	keypair := &Keypair {}
+	for i, val := range ints {
+		if val < 0 || val > 0xff {
+			keypair.Zero()
+			return nil, ErrInvalidJSON
+		}
+		keypair[i] = byte(val)
+	}
+	if err := keypair.validate(); err != nil {
+		keypair.Zero()
+		return nil, err
+	}
+	return keypair, nil
+}
+
+// Get the Solana CLI JSON keypair file content (an array of 64 integers).
+func (keypair *Keypair) JSON() []byte {
+	buf := make([]byte, 0, KeypairLen * 4 + 1)
+	buf = append(buf, '[')
+	for i, b := range keypair {
+		if i != 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendUint(buf, uint64(b), 10)
+	}
+	return append(buf, ']')
+}
+
+// Get the ed25519 private key.
+func (keypair *Keypair) Ed25519() ed25519.PrivateKey {
+	return append(ed25519.PrivateKey {}, keypair[:]...)
+}
+
+// Get the public key.
+func (keypair *Keypair) PublicKey() PublicKey {
+	var pubKey PublicKey
+	copy(pubKey[:], keypair[ed25519.SeedSize:])
+	return pubKey
+}
+
+// Get the keypair string.
+func (keypair *Keypair) String() string {
+	return base58Btc.Encode(keypair[:])
+}
+
+// Zero the keypair.
+func (keypair *Keypair) Zero() {
+	for i := range keypair {
+		keypair[i] = 0
+	}
+}
+
+//
+// Not-exported functions
+//
+
+// Decode the specified string into the specified slice, whose length shall be matched exactly
+func decodeExact(dst []byte, input string) error {
+	n, err := base58Btc.DecodeInto(dst, input)
+	if err == base58.ErrBufferTooSmall {
+		return ErrInvalidLength
+	}
+	if err != nil {
+		return err
+	}
+	if n != len(dst) {
+		return ErrInvalidLength
+	}
+	return nil
+}
+
+// Validate the keypair, by checking that the public key is derived from the seed
+func (keypair *Keypair) validate() error {
+	privKey := ed25519.NewKeyFromSeed(keypair[:ed25519.SeedSize])
+	defer zeroBytes(privKey)
+
+	if !bytes.Equal(privKey[ed25519.SeedSize:], keypair[ed25519.SeedSize:]) {
+		return ErrInvalidKeypair
+	}
+	return nil
+}
+
+// Zero the specified byte slice
+func zeroBytes(slice []byte) {
+	for i := range slice {
+		slice[i] = 0
+	}
+}
+
+// Zero the specified int slice
+func zeroInts(slice []int) {
+	for i := range slice {
+		slice[i] = 0
+	}
+}
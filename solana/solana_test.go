@@ -0,0 +1,225 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package solana
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+//
+// Variables
+//
+
+// Public keys
+var testVectPublicKey = []struct {
+	Key string
+	Hex string
+} {
+	// System program
+	{ "11111111111111111111111111111111", "0000000000000000000000000000000000000000000000000000000000000000" },
+	// Token program
+	{ "TokenkegQfeZyiNwAJbNbGqPFXCWuBvf9Ss623VQ5DA", "06ddf6e1d765a193d9cbe146ceeb79ac20e4d614b197a3935b185f3f9edf00a9" },
+	// RFC 8032 test 1
+	{ "FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z", "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a" },
+}
+
+// Keypair (RFC 8032 test 1)
+var testVectKeypair = struct {
+	Keypair string
+	Seed    string
+	JSON    string
+	PubKey  string
+	// Signature of empty message
+	Sig     string
+	SigHex  string
+} {
+	Keypair: "49W385L4rePHy6PAaQUovbD2aacgN4HsKXSMeUzRg4fmwXszN91JuMFrQRj3vMDpZuRF3ZknQBuRBoWQJEfXstMw",
+	Seed:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+	JSON:    "[157,97,177,157,239,253,90,96,186,132,74,244,146,236,44,196,68,73,197,105,123,50,105,25,112,59,172,3,28,174,127,96," +
+	         "215,90,152,1,130,177,10,183,213,75,254,211,201,100,7,58,14,225,114,243,218,166,35,37,175,2,26,104,247,7,81,26]",
+	PubKey:  "FVen3X669xLzsi6N2V91DoiyzHzg1uAgqiT8jZ9nS96Z",
+	Sig:     "5awYiUvGiDFA33EJjj4TXJG44a5afJc8QjWRpGgQiu6b23jCr7yndW2fmp9ujwqJVe32J456wV3VF78Asb1obnTc",
+	SigHex:  "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+}
+
+//
+// Functions
+//
+
+// Test public keys
+func TestPublicKey(t *testing.T) {
+	for _, currTest := range testVectPublicKey {
+		pubKey, err := DecodePublicKey(currTest.Key)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Key, err.Error())
+			continue
+		}
+		if hex.EncodeToString(pubKey[:]) != currTest.Hex {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Key, currTest.Hex, pubKey[:])
+		}
+		if pubKey.String() != currTest.Key {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Key, pubKey.String())
+		}
+
+		// Conversion from/to ed25519
+		edPubKey := pubKey.Ed25519()
+		if pubKey2, err := PublicKeyFromEd25519(edPubKey); err != nil || pubKey2 != pubKey {
+			t.Errorf("Converting (%s) from/to ed25519 was incorrect", currTest.Key)
+		}
+	}
+}
+
+// Test signatures
+func TestSignature(t *testing.T) {
+	sig, err := DecodeSignature(testVectKeypair.Sig)
+	if err != nil {
+		t.Fatalf("Decoding (%s) returned error: %s", testVectKeypair.Sig, err.Error())
+	}
+	if hex.EncodeToString(sig[:]) != testVectKeypair.SigHex {
+		t.Errorf("Decoding (%s) was incorrect: got %x", testVectKeypair.Sig, sig[:])
+	}
+	if sig.String() != testVectKeypair.Sig {
+		t.Errorf("Encoding was incorrect: expected %s, got: %s", testVectKeypair.Sig, sig.String())
+	}
+
+	// Verify signature with decoded public key
+	pubKey, _ := DecodePublicKey(testVectKeypair.PubKey)
+	if !ed25519.Verify(pubKey.Ed25519(), nil, sig[:]) {
+		t.Errorf("Signature verification failed")
+	}
+}
+
+// Test keypairs
+func TestKeypair(t *testing.T) {
+	seed, _ := hex.DecodeString(testVectKeypair.Seed)
+	privKey := ed25519.NewKeyFromSeed(seed)
+
+	// Decode
+	keypair, err := DecodeKeypair(testVectKeypair.Keypair)
+	if err != nil {
+		t.Fatalf("Decoding (%s) returned error: %s", testVectKeypair.Keypair, err.Error())
+	}
+	if !bytes.Equal(keypair.Ed25519(), privKey) {
+		t.Errorf("Decoding (%s) was incorrect: got %x", testVectKeypair.Keypair, keypair[:])
+	}
+	if keypair.PublicKey().String() != testVectKeypair.PubKey {
+		t.Errorf("Keypair public key was incorrect: got %s", keypair.PublicKey().String())
+	}
+	if keypair.String() != testVectKeypair.Keypair {
+		t.Errorf("Encoding was incorrect: expected %s, got: %s", testVectKeypair.Keypair, keypair.String())
+	}
+
+	// From ed25519
+	keypair, err = KeypairFromEd25519(privKey)
+	if err != nil || keypair.String() != testVectKeypair.Keypair {
+		t.Errorf("Creating keypair from ed25519 was incorrect")
+	}
+	if sig := ed25519.Sign(keypair.Ed25519(), nil); hex.EncodeToString(sig) != testVectKeypair.SigHex {
+		t.Errorf("Signing with keypair was incorrect: got %x", sig)
+	}
+
+	// JSON
+	if string(keypair.JSON()) != testVectKeypair.JSON {
+		t.Errorf("Encoding JSON was incorrect: got %s", keypair.JSON())
+	}
+	keypair, err = KeypairFromJSON([]byte(testVectKeypair.JSON))
+	if err != nil || keypair.String() != testVectKeypair.Keypair {
+		t.Errorf("Decoding JSON was incorrect")
+	}
+	// Whitespaces are allowed
+	keypair, err = KeypairFromJSON([]byte(strings.Replace(testVectKeypair.JSON, ",", ", ", -1) + "\n"))
+	if err != nil || keypair.String() != testVectKeypair.Keypair {
+		t.Errorf("Decoding JSON with whitespaces was incorrect")
+	}
+
+	// Zero
+	keypair.Zero()
+	if *keypair != (Keypair {}) {
+		t.Errorf("Zeroing keypair was incorrect")
+	}
+}
+
+// Test invalid inputs
+func TestInvalid(t *testing.T) {
+	// Invalid lengths
+	if _, err := DecodePublicKey(testVectPublicKey[0].Key[1:]); err != ErrInvalidLength {
+		t.Errorf("Decoding short public key returned wrong error")
+	}
+	if _, err := DecodePublicKey(testVectPublicKey[1].Key + "1"); err != ErrInvalidLength {
+		t.Errorf("Decoding long public key returned wrong error")
+	}
+	if _, err := DecodePublicKey(testVectKeypair.Sig); err != ErrInvalidLength {
+		t.Errorf("Decoding signature as public key returned wrong error")
+	}
+	if _, err := DecodeSignature(testVectKeypair.PubKey); err != ErrInvalidLength {
+		t.Errorf("Decoding public key as signature returned wrong error")
+	}
+	if _, err := DecodeKeypair(testVectKeypair.PubKey); err != ErrInvalidLength {
+		t.Errorf("Decoding public key as keypair returned wrong error")
+	}
+	if _, err := PublicKeyFromEd25519(make([]byte, 31)); err != ErrInvalidLength {
+		t.Errorf("Creating public key from short ed25519 key returned wrong error")
+	}
+	if _, err := KeypairFromEd25519(make([]byte, 32)); err != ErrInvalidLength {
+		t.Errorf("Creating keypair from short ed25519 key returned wrong error")
+	}
+
+	// Invalid characters
+	if _, err := DecodePublicKey("0" + testVectPublicKey[1].Key[1:]); err == nil {
+		t.Errorf("Decoding public key with invalid character returned no error")
+	}
+
+	// Public key not matching the seed
+	keypair, _ := DecodeKeypair(testVectKeypair.Keypair)
+	keypair[KeypairLen - 1] ^= 0x01
+	if _, err := DecodeKeypair(keypair.String()); err != ErrInvalidKeypair {
+		t.Errorf("Decoding keypair with wrong public key returned wrong error")
+	}
+	if _, err := KeypairFromEd25519(keypair.Ed25519()); err != ErrInvalidKeypair {
+		t.Errorf("Creating keypair from ed25519 with wrong public key returned wrong error")
+	}
+	if _, err := KeypairFromJSON(keypair.JSON()); err != ErrInvalidKeypair {
+		t.Errorf("Decoding JSON keypair with wrong public key returned wrong error")
+	}
+
+	// Invalid JSON
+	invalidJSON := []string {
+		"",
+		"{}",
+		"[1,2,3]",
+		"\"" + testVectKeypair.Keypair + "\"",
+		strings.Replace(testVectKeypair.JSON, "[157,", "[256,", 1),
+		strings.Replace(testVectKeypair.JSON, "[157,", "[-1,", 1),
+		strings.Replace(testVectKeypair.JSON, "[157,", "[157,1,", 1),
+	}
+	for _, currTest := range invalidJSON {
+		if _, err := KeypairFromJSON([]byte(currTest)); err != ErrInvalidJSON {
+			t.Errorf("Decoding invalid JSON keypair (%s) returned wrong error", currTest)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Substrate SS58 addresses, built on top of Base58 with Blake2b-512 checksum.
+//
+
+// Package ss58 implements the SS58 address format used by Substrate-based networks (Polkadot, Kusama, ...).
+package ss58
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Polkadot network prefix
+	PrefixPolkadot = 0
+	// Kusama network prefix
+	PrefixKusama = 2
+	// Generic Substrate network prefix
+	PrefixSubstrate = 42
+
+	// Maximum network prefix (14 bits)
+	MaxPrefix = 16383
+	// Account ID length
+	AccountIDLen = 32
+
+	// Maximum network prefix encoded in a single byte
+	maxSimplePrefix = 63
+	// Flag of the first byte for 2-byte network prefixes
+	fullPrefixFlag = 0x40
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidPrefix is returned when the network prefix is not valid or reserved
+	ErrInvalidPrefix = errors.New("The network prefix is not valid")
+	// ErrInvalidLength is returned when the payload length is not valid
+	ErrInvalidLength = errors.New("The payload length is not valid")
+
+	// Map from payload length to checksum length (account indices and account IDs)
+	checksumLenMap = map[int]int {
+		1:  1,
+		2:  1,
+		4:  1,
+		8:  1,
+		32: 2,
+		33: 2,
+	}
+
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Exported functions
+//
+
+// Encode the specified payload for the specified network prefix.
+// The payload is an account ID (32 or 33 bytes) or an account index (1, 2, 4 or 8 bytes).
+func Encode(prefix uint16, payload []byte) (string, error) {
+	chksumLen, ok := checksumLenMap[len(payload)]
+	if !ok {
+		return "", ErrInvalidLength
+	}
+
+	data, err := encodePrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+	data = append(data, payload...)
+	data = append(data, computeChecksum(data, chksumLen)...)
+
+	return base58Btc.Encode(data), nil
+}
+
+// Decode the specified address, returning its network prefix and payload.
+func Decode(addr string) (uint16, []byte, error) {
+	dec, err := base58Btc.Decode(addr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	prefix, prefixLen, err := decodePrefix(dec)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Get payload length from the remaining length
+	payloadLen, chksumLen := 0, 0
+	for currPayloadLen, currChksumLen := range checksumLenMap {
+		if prefixLen + currPayloadLen + currChksumLen == len(dec) {
+			payloadLen, chksumLen = currPayloadLen, currChksumLen
+			break
+		}
+	}
+	if payloadLen == 0 {
+		return 0, nil, ErrInvalidLength
+	}
+
+	// Verify checksum
+	chksumIdx := len(dec) - chksumLen
+	if !bytes.Equal(dec[chksumIdx:], computeChecksum(dec[:chksumIdx], chksumLen)) {
+		return 0, nil, base58.ErrInvalidChecksum
+	}
+
+	return prefix, dec[prefixLen:chksumIdx], nil
+}
+
+// Decode the specified address, checking that it belongs to the specified network prefix.
+func DecodeForPrefix(addr string, prefix uint16) ([]byte, error) {
+	addrPrefix, payload, err := Decode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if addrPrefix != prefix {
+		return nil, ErrInvalidPrefix
+	}
+
+	return payload, nil
+}
+
+// Encode again the specified address for a different network prefix.
+func Reencode(addr string, prefix uint16) (string, error) {
+	_, payload, err := Decode(addr)
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(prefix, payload)
+}
+
+//
+// Not-exported functions
+//
+
+// Encode the network prefix to 1 byte (up to 63) or 2 bytes (up to 16383)
+func encodePrefix(prefix uint16) ([]byte, error) {
+	if !isValidPrefix(prefix) {
+		return nil, ErrInvalidPrefix
+	}
+
+	if prefix <= maxSimplePrefix {
+		return []byte { byte(prefix) }, nil
+	}
+	return []byte {
+		byte((prefix & 0xfc) >> 2) | fullPrefixFlag,
+		byte(prefix >> 8) | byte((prefix & 0x03) << 6),
+	}, nil
+}
+
+// Decode the network prefix, returning also its length
+func decodePrefix(data []byte) (uint16, int, error) {
+	if len(data) == 0 {
+		return 0, 0, ErrInvalidLength
+	}
+
+	// Simple prefix
+	if data[0] <= maxSimplePrefix {
+		if !isValidPrefix(uint16(data[0])) {
+			return 0, 0, ErrInvalidPrefix
+		}
+		return uint16(data[0]), 1, nil
+	}
+	// First byte shall be in [64, 127] for full prefix
+	if data[0] & 0xc0 != fullPrefixFlag {
+		return 0, 0, ErrInvalidPrefix
+	}
+	if len(data) < 2 {
+		return 0, 0, ErrInvalidLength
+	}
+
+	lower := (data[0] << 2) | (data[1] >> 6)
+	upper := data[1] & 0x3f
+	prefix := uint16(lower) | uint16(upper) << 8
+
+	// Prefixes up to 63 shall be encoded as simple prefix
+	if prefix <= maxSimplePrefix || !isValidPrefix(prefix) {
+		return 0, 0, ErrInvalidPrefix
+	}
+
+	return prefix, 2, nil
+}
+
+// Get if the network prefix is valid (46 and 47 are reserved)
+func isValidPrefix(prefix uint16) bool {
+	return prefix <= MaxPrefix && prefix != 46 && prefix != 47
+}
+
+// Compute the checksum of the specified length, i.e. a truncation of the Blake2b-512 of "SS58PRE" followed by the data
+func computeChecksum(data []byte, chksumLen int) []byte {
+	return base58.ChecksumBlake2b512.Compute(data)[:chksumLen]
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ss58
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Addr    string
+	Prefix  uint16
+	Payload string
+}
+
+//
+// Variables
+//
+
+// Alice account ID
+const testAliceAccountID = "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+// Valid addresses
+var testVectValid = []testVectEntry {
+	// Alice on Polkadot, Kusama and generic Substrate (from Substrate subkey)
+	testVectEntry {
+		Addr:    "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5",
+		Prefix:  PrefixPolkadot,
+		Payload: testAliceAccountID,
+	},
+	testVectEntry {
+		Addr:    "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F",
+		Prefix:  PrefixKusama,
+		Payload: testAliceAccountID,
+	},
+	testVectEntry {
+		Addr:    "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		Prefix:  PrefixSubstrate,
+		Payload: testAliceAccountID,
+	},
+	// 2-byte network prefixes
+	testVectEntry {
+		Addr:    "cEaNSpz4PxFcZ7nT1VEKrKewH67rfx6MfcM6yKojyyPz7qaqp",
+		Prefix:  64,
+		Payload: testAliceAccountID,
+	},
+	testVectEntry {
+		Addr:    "yGHXkYLYqxijLKKfd9Q2CB9shRVu8rPNBS53wvwGTutYg4zTg",
+		Prefix:  255,
+		Payload: testAliceAccountID,
+	},
+	testVectEntry {
+		Addr:    "yNa8JpqfFB3q8A29rCwSgxvdU94ufJw2yKKxDgznS5m1PoFvn",
+		Prefix:  MaxPrefix,
+		Payload: testAliceAccountID,
+	},
+	// Account indices
+	testVectEntry {
+		Addr:    "F7NZ",
+		Prefix:  PrefixSubstrate,
+		Payload: "01",
+	},
+	testVectEntry {
+		Addr:    "17bWpUX",
+		Prefix:  PrefixPolkadot,
+		Payload: "01020304",
+	},
+	testVectEntry {
+		Addr:    "7XjedFF7Suor7",
+		Prefix:  PrefixKusama,
+		Payload: "0102030405060708",
+	},
+}
+
+//
+// Functions
+//
+
+// Test valid addresses
+func TestValid(t *testing.T) {
+	for _, currTest := range testVectValid {
+		payload, _ := hex.DecodeString(currTest.Payload)
+
+		// Encode
+		addr, err := Encode(currTest.Prefix, payload)
+		if err != nil {
+			t.Errorf("Encoding (%s) returned error: %s", currTest.Payload, err.Error())
+		} else if addr != currTest.Addr {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Addr, addr)
+		}
+
+		// Decode
+		prefix, dec, err := Decode(currTest.Addr)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if prefix != currTest.Prefix || hex.EncodeToString(dec) != currTest.Payload {
+			t.Errorf("Decoding (%s) was incorrect: got %d %x", currTest.Addr, prefix, dec)
+		}
+
+		// Decode for prefix
+		if _, err = DecodeForPrefix(currTest.Addr, currTest.Prefix); err != nil {
+			t.Errorf("Decoding (%s) for its prefix returned error: %s", currTest.Addr, err.Error())
+		}
+		if _, err = DecodeForPrefix(currTest.Addr, currTest.Prefix + 1); err != ErrInvalidPrefix {
+			t.Errorf("Decoding (%s) for wrong prefix returned wrong error", currTest.Addr)
+		}
+	}
+}
+
+// Test re-encoding for a different network
+func TestReencode(t *testing.T) {
+	for _, currTest := range testVectValid[:6] {
+		for _, newTest := range testVectValid[:6] {
+			addr, err := Reencode(currTest.Addr, newTest.Prefix)
+			if err != nil {
+				t.Errorf("Re-encoding (%s) returned error: %s", currTest.Addr, err.Error())
+			} else if addr != newTest.Addr {
+				t.Errorf("Re-encoding (%s) was incorrect: expected %s, got: %s", currTest.Addr, newTest.Addr, addr)
+			}
+		}
+	}
+}
+
+// Test invalid inputs
+func TestInvalid(t *testing.T) {
+	accountID, _ := hex.DecodeString(testAliceAccountID)
+	base58Btc := base58.New(base58.AlphabetBitcoin)
+
+	// Invalid prefixes
+	for _, prefix := range []uint16 { 46, 47, MaxPrefix + 1 } {
+		if _, err := Encode(prefix, accountID); err != ErrInvalidPrefix {
+			t.Errorf("Encoding with invalid prefix (%d) returned wrong error", prefix)
+		}
+		if _, err := Reencode(testVectValid[0].Addr, prefix); err != ErrInvalidPrefix {
+			t.Errorf("Re-encoding with invalid prefix (%d) returned wrong error", prefix)
+		}
+	}
+
+	// Invalid payload lengths
+	for _, payloadLen := range []int { 0, 3, 16, 31, 34 } {
+		if _, err := Encode(PrefixPolkadot, make([]byte, payloadLen)); err != ErrInvalidLength {
+			t.Errorf("Encoding payload of length %d returned wrong error", payloadLen)
+		}
+	}
+
+	testVectInvalid := []struct {
+		Addr string
+		Err  error
+	} {
+		// Empty
+		{ "", ErrInvalidLength },
+		// Invalid checksum
+		{ "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp6", base58.ErrInvalidChecksum },
+		{ "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ", base58.ErrInvalidChecksum },
+		// Invalid length
+		{ base58Btc.Encode(append([]byte { 0x00 }, accountID...)), ErrInvalidLength },
+		{ base58Btc.Encode([]byte { 0x40 }), ErrInvalidLength },
+		// First byte not valid for any prefix
+		{ base58Btc.Encode(append([]byte { 0x80 }, accountID...)), ErrInvalidPrefix },
+		// Prefix lower than 64 encoded in 2 bytes
+		{ base58Btc.Encode(append([]byte { 0x4a, 0x80 }, accountID...)), ErrInvalidPrefix },
+		// Reserved prefix
+		{ base58Btc.Encode(append([]byte { 46 }, accountID...)), ErrInvalidPrefix },
+	}
+	for _, currTest := range testVectInvalid {
+		if _, _, err := Decode(currTest.Addr); err != currTest.Err {
+			t.Errorf("Decoding invalid address (%s) returned wrong error: %v", currTest.Addr, err)
+		}
+	}
+	if _, _, err := Decode("0GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding address with invalid character returned wrong error")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Tron addresses, built on top of Base58Check.
+//
+
+// Package tron implements Tron addresses, in base58 ("T...") and hex ("41...") forms.
+package tron
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+	"github.com/ebellocchia/go-base58/internal/keccak"
+)
+
+//
+// Constants
+//
+const (
+	// Address length, including the prefix
+	AddressLen = 1 + HashLen
+	// Hash length (last 20 bytes of the Keccak-256 of the public key)
+	HashLen = 20
+	// Uncompressed public key length
+	PublicKeyLen = 65
+
+	// Address prefix
+	addressPrefix = 0x41
+	// Uncompressed public key prefix
+	publicKeyPrefix = 0x04
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidLength is returned when the address length is not valid
+	ErrInvalidLength = errors.New("The address length is not valid")
+	// ErrInvalidPrefix is returned when the address prefix is not valid
+	ErrInvalidPrefix = errors.New("The address prefix is not valid")
+	// ErrInvalidPublicKey is returned when the public key is not a valid uncompressed public key
+	ErrInvalidPublicKey = errors.New("The public key is not valid")
+
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Address (0x41 prefix followed by the hash)
+type Address [AddressLen]byte
+
+//
+// Exported functions
+//
+
+// Create a new address from the specified hash.
+func New(hash []byte) (Address, error) {
+	if len(hash) != HashLen {
+		return Address {}, ErrInvalidLength
+	}
+
+	var addr Address
+	addr[0] = addressPrefix
+	copy(addr[1:], hash)
+
+	return addr, nil
+}
+
+// Create a new address from the specified uncompressed secp256k1 public key (65 bytes, prefixed by 0x04).
+// The address hash is the last 20 bytes of the Keccak-256 of the public key (without prefix).
+// The point is not checked to be on the curve.
+func FromPublicKey(pubKey []byte) (Address, error) {
+	if len(pubKey) != PublicKeyLen || pubKey[0] != publicKeyPrefix {
+		return Address {}, ErrInvalidPublicKey
+	}
+
+	digest := keccak.Sum256(pubKey[1:])
+	return New(digest[keccak.Size - HashLen:])
+}
+
+// Decode the specified base58 address ("T...").
+func Decode(addrStr string) (Address, error) {
+	dec, err := base58Btc.CheckDecode(addrStr)
+	if err != nil {
+		return Address {}, err
+	}
+
+	return fromBytes(dec)
+}
+
+// Decode the specified hex address ("41...", as used by Tron APIs).
+func DecodeHex(addrHex string) (Address, error) {
+	dec, err := hex.DecodeString(addrHex)
+	if err != nil {
+		return Address {}, err
+	}
+
+	return fromBytes(dec)
+}
+
+// Get if the specified string is a valid base58 address.
+func IsValid(addrStr string) bool {
+	_, err := Decode(addrStr)
+	return err == nil
+}
+
+// Get the address hash.
+func (addr Address) Hash() []byte {
+	return append([]byte {}, addr[1:]...)
+}
+
+// Get the hex address ("41...").
+func (addr Address) Hex() string {
+	return hex.EncodeToString(addr[:])
+}
+
+// Get the base58 address ("T...").
+func (addr Address) String() string {
+	return base58Btc.CheckEncode(addr[:])
+}
+
+//
+// Not-exported functions
+//
+
+// Create an address from the specified bytes, checking length and prefix
+func fromBytes(data []byte) (Address, error) {
+	if len(data) != AddressLen {
+		return Address {}, ErrInvalidLength
+	}
+	if data[0] != addressPrefix {
+		return Address {}, ErrInvalidPrefix
+	}
+
+	var addr Address
+	copy(addr[:], data)
+
+	return addr, nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tron
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Addr   string
+	Hex    string
+	PubKey string
+}
+
+//
+// Variables
+//
+
+// Addresses
+var testVectValid = []testVectEntry {
+	// USDT contract
+	testVectEntry {
+		Addr: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+		Hex:  "41a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+	},
+	// Private key 1
+	testVectEntry {
+		Addr:   "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC",
+		Hex:    "417e5f4552091a69125d5dfcb7b8c2659029395bdf",
+		PubKey: "0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+	},
+	// Private key 2
+	testVectEntry {
+		Addr:   "TDvSsdrNM5eeXNL3czpa6AxLDHZA9nwe9K",
+		Hex:    "412b5ad5c4795c026514f8317c7a215e218dccd6cf",
+		PubKey: "04c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee51ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a",
+	},
+}
+
+//
+// Functions
+//
+
+// Test valid addresses
+func TestValid(t *testing.T) {
+	for _, currTest := range testVectValid {
+		hash, _ := hex.DecodeString(currTest.Hex[2:])
+
+		// Decode base58
+		addr, err := Decode(currTest.Addr)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Addr, err.Error())
+			continue
+		}
+		if addr.Hex() != currTest.Hex || !bytes.Equal(addr.Hash(), hash) {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %s", currTest.Addr, currTest.Hex, addr.Hex())
+		}
+		if !IsValid(currTest.Addr) {
+			t.Errorf("Address (%s) reported as not valid", currTest.Addr)
+		}
+
+		// Decode hex
+		addr, err = DecodeHex(currTest.Hex)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Hex, err.Error())
+			continue
+		}
+		if addr.String() != currTest.Addr {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Addr, addr.String())
+		}
+
+		// From hash
+		addr, err = New(hash)
+		if err != nil || addr.String() != currTest.Addr {
+			t.Errorf("Creating address from hash (%s) was incorrect", currTest.Hex)
+		}
+
+		// From public key
+		if currTest.PubKey != "" {
+			pubKey, _ := hex.DecodeString(currTest.PubKey)
+			addr, err = FromPublicKey(pubKey)
+			if err != nil || addr.String() != currTest.Addr {
+				t.Errorf("Creating address from public key (%s) was incorrect: got %s", currTest.PubKey, addr.String())
+			}
+		}
+	}
+}
+
+// Test invalid addresses
+func TestInvalid(t *testing.T) {
+	hash, _ := hex.DecodeString(testVectValid[0].Hex[2:])
+	pubKey, _ := hex.DecodeString(testVectValid[1].PubKey)
+
+	testVectInvalid := []struct {
+		Addr string
+		Err  error
+	} {
+		// Invalid checksum
+		{ "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", base58.ErrInvalidChecksum },
+		// Bitcoin address
+		{ "1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i", ErrInvalidPrefix },
+		// Invalid length
+		{ base58.New(base58.AlphabetBitcoin).CheckEncodeVersion([]byte { 0x41 }, hash[1:]), ErrInvalidLength },
+		{ base58.New(base58.AlphabetBitcoin).CheckEncodeVersion([]byte { 0x41 }, append(hash, 0x00)), ErrInvalidLength },
+	}
+	for _, currTest := range testVectInvalid {
+		if _, err := Decode(currTest.Addr); err != currTest.Err {
+			t.Errorf("Decoding invalid address (%s) returned wrong error: %v", currTest.Addr, err)
+		}
+		if IsValid(currTest.Addr) {
+			t.Errorf("Invalid address (%s) reported as valid", currTest.Addr)
+		}
+	}
+	if _, err := Decode("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6O"); !errors.Is(err, base58.ErrInvalidFormat) {
+		t.Errorf("Decoding address with invalid character returned wrong error")
+	}
+
+	// Invalid hex
+	if _, err := DecodeHex("a614f803b6fd780986a42c78ec9c7f77e6ded13c"); err != ErrInvalidLength {
+		t.Errorf("Decoding hex address without prefix returned wrong error")
+	}
+	if _, err := DecodeHex("00a614f803b6fd780986a42c78ec9c7f77e6ded13c"); err != ErrInvalidPrefix {
+		t.Errorf("Decoding hex address with wrong prefix returned wrong error")
+	}
+	if _, err := DecodeHex("41a614f803b6fd780986a42c78ec9c7f77e6ded13"); err == nil {
+		t.Errorf("Decoding invalid hex address returned no error")
+	}
+
+	// Invalid hash and public key
+	if _, err := New(hash[1:]); err != ErrInvalidLength {
+		t.Errorf("Creating address with short hash returned wrong error")
+	}
+	if _, err := FromPublicKey(pubKey[1:]); err != ErrInvalidPublicKey {
+		t.Errorf("Creating address from public key without prefix returned wrong error")
+	}
+	if _, err := FromPublicKey(append([]byte { 0x02 }, pubKey[1:]...)); err != ErrInvalidPublicKey {
+		t.Errorf("Creating address from public key with wrong prefix returned wrong error")
+	}
+}
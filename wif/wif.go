@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the Wallet Import Format (WIF) private keys, built on top of Base58Check.
+//
+
+// Package wif implements the Wallet Import Format (WIF) for Bitcoin private keys.
+package wif
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// Bitcoin mainnet
+	Mainnet Network = 0
+	// Bitcoin testnet (also used by regtest)
+	Testnet Network = 1
+
+	// Private key length
+	KeyLen = 32
+
+	// Suffix for compressed public keys
+	compressedSuffix = 0x01
+	// Maximum decoded length (version, key, suffix and checksum)
+	maxDecodedLen = 1 + KeyLen + 1 + 4
+	// Maximum encoded length, i.e. base58.MaxEncodedLen(maxDecodedLen)
+	base58MaxEncodedLen = 52
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidNetwork is returned when using a not-existent network
+	ErrInvalidNetwork = errors.New("The specified network is not existent")
+	// ErrInvalidLength is returned when the key length is not valid
+	ErrInvalidLength = errors.New("The WIF key length is not valid")
+	// ErrInvalidVersion is returned when the version byte is not valid
+	ErrInvalidVersion = errors.New("The WIF version byte is not valid")
+	// ErrInvalidSuffix is returned when the compression suffix is not valid
+	ErrInvalidSuffix = errors.New("The WIF compression suffix is not valid")
+
+	// Map from network to version byte
+	versionMap = map[Network]byte {
+		Mainnet: 0x80,
+		Testnet: 0xef,
+	}
+	// Base58 object
+	base58Btc = base58.New(base58.AlphabetBitcoin)
+)
+
+//
+// Types
+//
+
+// Network type
+type Network int
+
+// WIF structure.
+// The key shall be zeroed with Zero when not needed anymore.
+type WIF struct {
+	Net        Network
+	Key        [KeyLen]byte
+	Compressed bool
+}
+
+//
+// Exported functions
+//
+
+// Create a new WIF from the specified network, private key and compression flag.
+func New(net Network, key []byte, compressed bool) (*WIF, error) {
+	if _, ok := versionMap[net]; !ok {
+		return nil, ErrInvalidNetwork
+	}
+	if len(key) != KeyLen {
+		return nil, ErrInvalidLength
+	}
+
+	wif := &WIF {
+		Net:        net,
+		Compressed: compressed,
+	}
+	copy(wif.Key[:], key)
+
+	return wif, nil
+}
+
+// Decode the specified WIF string.
+// The local decoding buffer is zeroed before returning, while internal base58 and checksum buffers are not.
+func Decode(wifStr string) (*WIF, error) {
+	// Decode into a local buffer, so that it can be zeroed
+	var buf [maxDecodedLen]byte
+	defer zeroBytes(buf[:])
+
+	n, err := base58Btc.DecodeInto(buf[:], wifStr)
+	if err == base58.ErrBufferTooSmall {
+		return nil, ErrInvalidLength
+	}
+	if err != nil {
+		return nil, err
+	}
+	dec := buf[:n]
+
+	// Check length, with or without the compression suffix
+	if n != maxDecodedLen && n != maxDecodedLen - 1 {
+		return nil, ErrInvalidLength
+	}
+
+	// Verify checksum
+	chksumIdx := n - base58.ChecksumDoubleSha256.Size()
+	if !bytes.Equal(dec[chksumIdx:], base58.ChecksumDoubleSha256.Compute(dec[:chksumIdx])) {
+		return nil, base58.ErrInvalidChecksum
+	}
+
+	// Get network from version
+	net, err := getNetwork(dec[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// Check compression suffix
+	compressed := n == maxDecodedLen
+	if compressed && dec[1 + KeyLen] != compressedSuffix {
+		return nil, ErrInvalidSuffix
+	}
+
+	return New(net, dec[1:1 + KeyLen], compressed)
+}
+
+// Encode the WIF.
+// It returns an error if the network is not valid.
+// The local payload and encoding buffers are zeroed before returning, while internal base58 and checksum buffers are not.
+func (wif *WIF) Encode() (string, error) {
+	version, ok := versionMap[wif.Net]
+	if !ok {
+		return "", ErrInvalidNetwork
+	}
+
+	// Build payload into a local buffer, so that it can be zeroed
+	var buf [maxDecodedLen]byte
+	defer zeroBytes(buf[:])
+
+	buf[0] = version
+	copy(buf[1:], wif.Key[:])
+	n := 1 + KeyLen
+	if wif.Compressed {
+		buf[n] = compressedSuffix
+		n++
+	}
+	n += copy(buf[n:], base58.ChecksumDoubleSha256.Compute(buf[:n]))
+
+	var enc [base58MaxEncodedLen]byte
+	defer zeroBytes(enc[:])
+
+	return string(base58Btc.AppendEncode(enc[:0], buf[:n])), nil
+}
+
+// Get the WIF string.
+// It returns an empty string if the network is not valid, use Encode for getting the error.
+func (wif *WIF) String() string {
+	enc, _ := wif.Encode()
+	return enc
+}
+
+// Zero the private key.
+func (wif *WIF) Zero() {
+	zeroBytes(wif.Key[:])
+}
+
+//
+// Not-exported functions
+//
+
+// Get the network from the specified version byte
+func getNetwork(version byte) (Network, error) {
+	for net, currVersion := range versionMap {
+		if currVersion == version {
+			return net, nil
+		}
+	}
+	return 0, ErrInvalidVersion
+}
+
+// Zero the specified byte slice
+func zeroBytes(slice []byte) {
+	for i := range slice {
+		slice[i] = 0
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package wif
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure
+type testVectEntry struct {
+	Wif        string
+	Key        string
+	Net        Network
+	Compressed bool
+}
+
+//
+// Variables
+//
+
+// Valid WIF keys (from Bitcoin wiki and Bitcoin Core base58_keys_valid.json)
+var testVectValid = []testVectEntry {
+	testVectEntry {
+		Wif:        "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ",
+		Key:        "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d",
+		Net:        Mainnet,
+		Compressed: false,
+	},
+	testVectEntry {
+		Wif:        "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617",
+		Key:        "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d",
+		Net:        Mainnet,
+		Compressed: true,
+	},
+	testVectEntry {
+		Wif:        "5Kd3NBUAdUnhyzenEwVLy9pBKxSwXvE9FMPyR4UKZvpe6E3AgLr",
+		Key:        "eddbdc1168f1daeadbd3e44c1e3f8f5a284c2029f78ad26af98583a499de5b19",
+		Net:        Mainnet,
+		Compressed: false,
+	},
+	testVectEntry {
+		Wif:        "Kz6UJmQACJmLtaQj5A3JAge4kVTNQ8gbvXuwbmCj7bsaabudb3RD",
+		Key:        "55c9bccb9ed68446d1b75273bbce89d7fe013a8acd1625514420fb2aca1a21c4",
+		Net:        Mainnet,
+		Compressed: true,
+	},
+	testVectEntry {
+		Wif:        "9213qJab2HNEpMpYNBa7wHGFKKbkDn24jpANDs2huN3yi4J11ko",
+		Key:        "36cb93b9ab1bdabf7fb9f2c04f1b9cc879933530ae7842398eef5a63a56800c2",
+		Net:        Testnet,
+		Compressed: false,
+	},
+	testVectEntry {
+		Wif:        "cTpB4YiyKiBcPxnefsDpbnDxFDffjqJob8wGCEDXxgQ7zQoMXJdH",
+		Key:        "b9f4892c9e8282028fea1d2667c4dc5213564d41fc5783896a0d843fc15089f3",
+		Net:        Testnet,
+		Compressed: true,
+	},
+}
+
+//
+// Functions
+//
+
+// Test valid WIF keys
+func TestValid(t *testing.T) {
+	for _, currTest := range testVectValid {
+		key, _ := hex.DecodeString(currTest.Key)
+
+		// Decode
+		wif, err := Decode(currTest.Wif)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Wif, err.Error())
+			continue
+		}
+		if wif.Net != currTest.Net || wif.Compressed != currTest.Compressed || hex.EncodeToString(wif.Key[:]) != currTest.Key {
+			t.Errorf("Decoding (%s) was incorrect", currTest.Wif)
+		}
+
+		// Encode
+		wif, err = New(currTest.Net, key, currTest.Compressed)
+		if err != nil {
+			t.Errorf("Creating WIF (%s) returned error: %s", currTest.Wif, err.Error())
+			continue
+		}
+		if wif.String() != currTest.Wif {
+			t.Errorf("Encoding was incorrect: expected %s, got: %s", currTest.Wif, wif.String())
+		}
+
+		// Zero
+		wif.Zero()
+		if wif.Key != [KeyLen]byte {} {
+			t.Errorf("Zeroing key was not effective")
+		}
+	}
+}
+
+// Test invalid WIF keys
+func TestInvalid(t *testing.T) {
+	base58Btc := base58.New(base58.AlphabetBitcoin)
+	key, _ := hex.DecodeString("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+
+	testVect := map[string]error {
+		// Invalid checksum
+		"5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK": base58.ErrInvalidChecksum,
+		"KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98618": base58.ErrInvalidChecksum,
+		// Invalid lengths
+		"": ErrInvalidLength,
+		base58Btc.CheckEncodeVersion([]byte { 0x80 }, key[1:]): ErrInvalidLength,
+		base58Btc.CheckEncodeVersion([]byte { 0x80 }, append(key, 0x01, 0x00)): ErrInvalidLength,
+		base58Btc.CheckEncodeVersion([]byte { 0x80 }, make([]byte, 64)): ErrInvalidLength,
+		// Invalid version
+		base58Btc.CheckEncodeVersion([]byte { 0x00 }, key): ErrInvalidVersion,
+		base58Btc.CheckEncodeVersion([]byte { 0x6f }, append(key, 0x01)): ErrInvalidVersion,
+		// Invalid suffix
+		base58Btc.CheckEncodeVersion([]byte { 0x80 }, append(key, 0x02)): ErrInvalidSuffix,
+		base58Btc.CheckEncodeVersion([]byte { 0xef }, append(key, 0x00)): ErrInvalidSuffix,
+	}
+
+	for wifStr, expErr := range testVect {
+		wif, err := Decode(wifStr)
+		if wif != nil {
+			t.Errorf("Decoding invalid WIF (%s) returned not-nil result", wifStr)
+		}
+		if err != expErr {
+			t.Errorf("Decoding invalid WIF (%s) returned wrong error: expected %v, got: %v", wifStr, expErr, err)
+		}
+	}
+
+	// Invalid parameters
+	if _, err := New(Network(2), key, false); err != ErrInvalidNetwork {
+		t.Errorf("Creating WIF with invalid network returned wrong error")
+	}
+	if _, err := New(Mainnet, key[1:], false); err != ErrInvalidLength {
+		t.Errorf("Creating WIF with invalid key returned wrong error")
+	}
+
+	// Invalid network
+	wif := &WIF { Net: Network(9) }
+	if enc, err := wif.Encode(); enc != "" || err != ErrInvalidNetwork {
+		t.Errorf("Encoding WIF with invalid network returned wrong result")
+	}
+	if wif.String() != "" {
+		t.Errorf("Getting string of WIF with invalid network was not empty")
+	}
+
+	// The encoding buffer shall be big enough
+	if base58.MaxEncodedLen(maxDecodedLen) > base58MaxEncodedLen {
+		t.Errorf("Encoding buffer is too small")
+	}
+}
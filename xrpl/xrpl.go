@@ -0,0 +1,274 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file cointains the XRP Ledger address codec, built on top of Base58Check with the Ripple alphabet.
+//
+
+// Package xrpl implements the XRP Ledger encoding of account IDs, seeds, node public keys and X-addresses.
+package xrpl
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Constants
+//
+const (
+	// secp256k1 key type
+	Secp256k1 KeyType = 0
+	// ed25519 key type
+	Ed25519 KeyType = 1
+
+	// Account ID length
+	AccountIDLen = 20
+	// Seed length
+	SeedLen = 16
+	// Node public key length
+	NodePublicKeyLen = 33
+
+	// Account ID version
+	accountIDVersion = 0x00
+	// Node public key version
+	nodePublicKeyVersion = 0x1c
+	// secp256k1 seed version
+	seedVersion = 0x21
+	// X-address payload length (prefix, account ID, tag flag, 64-bit tag)
+	xAddressLen = 2 + AccountIDLen + 1 + 8
+)
+
+//
+// Variables
+//
+var (
+	// ErrInvalidKeyType is returned when using a not-existent key type
+	ErrInvalidKeyType = errors.New("The specified key type is not existent")
+	// ErrInvalidLength is returned when the payload length is not valid
+	ErrInvalidLength = errors.New("The payload length is not valid")
+	// ErrInvalidVersion is returned when the version bytes are not valid
+	ErrInvalidVersion = errors.New("The version bytes are not valid")
+	// ErrInvalidTag is returned when the X-address tag flag or tag is not valid
+	ErrInvalidTag = errors.New("The X-address tag is not valid")
+
+	// ed25519 seed version
+	ed25519SeedVersion = []byte { 0x01, 0xe1, 0x4b }
+	// X-address prefix for main network
+	xAddressMainPrefix = []byte { 0x05, 0x44 }
+	// X-address prefix for test network
+	xAddressTestPrefix = []byte { 0x04, 0x93 }
+
+	// Base58 object
+	base58Xrp = base58.New(base58.AlphabetRipple)
+)
+
+//
+// Types
+//
+
+// Key type
+type KeyType int
+
+// X-address structure.
+// The destination tag is only meaningful if HasTag is true.
+type XAddress struct {
+	AccountID [AccountIDLen]byte
+	Tag       uint32
+	HasTag    bool
+	Test      bool
+}
+
+//
+// Exported functions
+//
+
+// Encode the specified account ID to a classic address ("r...").
+func EncodeAccountID(accountID []byte) (string, error) {
+	if len(accountID) != AccountIDLen {
+		return "", ErrInvalidLength
+	}
+	return base58Xrp.CheckEncodeVersion([]byte { accountIDVersion }, accountID), nil
+}
+
+// Decode the specified classic address ("r...") to account ID.
+func DecodeAccountID(addr string) ([]byte, error) {
+	return decodeVersion(addr, []byte { accountIDVersion }, AccountIDLen)
+}
+
+// Encode the specified node public key ("n...").
+func EncodeNodePublicKey(key []byte) (string, error) {
+	if len(key) != NodePublicKeyLen {
+		return "", ErrInvalidLength
+	}
+	return base58Xrp.CheckEncodeVersion([]byte { nodePublicKeyVersion }, key), nil
+}
+
+// Decode the specified node public key ("n...").
+func DecodeNodePublicKey(key string) ([]byte, error) {
+	return decodeVersion(key, []byte { nodePublicKeyVersion }, NodePublicKeyLen)
+}
+
+// Encode the specified family seed ("s..." for secp256k1, "sEd..." for ed25519).
+func EncodeSeed(seed []byte, keyType KeyType) (string, error) {
+	if len(seed) != SeedLen {
+		return "", ErrInvalidLength
+	}
+
+	switch keyType {
+	case Secp256k1:
+		return base58Xrp.CheckEncodeVersion([]byte { seedVersion }, seed), nil
+	case Ed25519:
+		return base58Xrp.CheckEncodeVersion(ed25519SeedVersion, seed), nil
+	default:
+		return "", ErrInvalidKeyType
+	}
+}
+
+// Decode the specified family seed, returning also its key type.
+// The key type is identified from the version bytes.
+func DecodeSeed(seed string) ([]byte, KeyType, error) {
+	dec, err := base58Xrp.CheckDecode(seed)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch len(dec) {
+	case 1 + SeedLen:
+		if dec[0] != seedVersion {
+			return nil, 0, ErrInvalidVersion
+		}
+		return dec[1:], Secp256k1, nil
+	case len(ed25519SeedVersion) + SeedLen:
+		if !bytes.HasPrefix(dec, ed25519SeedVersion) {
+			return nil, 0, ErrInvalidVersion
+		}
+		return dec[len(ed25519SeedVersion):], Ed25519, nil
+	default:
+		return nil, 0, ErrInvalidLength
+	}
+}
+
+// Create a new X-address from the specified classic address, tag and network.
+func NewXAddress(classicAddr string, tag uint32, hasTag bool, test bool) (*XAddress, error) {
+	accountID, err := DecodeAccountID(classicAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	xAddr := &XAddress {
+		Tag:    tag,
+		HasTag: hasTag,
+		Test:   test,
+	}
+	copy(xAddr.AccountID[:], accountID)
+
+	if !hasTag {
+		xAddr.Tag = 0
+	}
+
+	return xAddr, nil
+}
+
+// Decode the specified X-address ("X..." for main network, "T..." for test network).
+func DecodeXAddress(addr string) (*XAddress, error) {
+	dec, err := base58Xrp.CheckDecode(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(dec) != xAddressLen {
+		return nil, ErrInvalidLength
+	}
+
+	// Get network from prefix
+	xAddr := &XAddress {}
+	switch {
+	case bytes.HasPrefix(dec, xAddressMainPrefix):
+		xAddr.Test = false
+	case bytes.HasPrefix(dec, xAddressTestPrefix):
+		xAddr.Test = true
+	default:
+		return nil, ErrInvalidVersion
+	}
+	copy(xAddr.AccountID[:], dec[2:])
+
+	// Get tag, only 32-bit tags are supported
+	flag := dec[2 + AccountIDLen]
+	tag := binary.LittleEndian.Uint64(dec[2 + AccountIDLen + 1:])
+	if flag > 1 || tag > 0xffffffff || (flag == 0 && tag != 0) {
+		return nil, ErrInvalidTag
+	}
+	xAddr.HasTag = flag == 1
+	xAddr.Tag = uint32(tag)
+
+	return xAddr, nil
+}
+
+// Get the classic address ("r...") of the X-address.
+func (xAddr *XAddress) ClassicAddress() string {
+	addr, _ := EncodeAccountID(xAddr.AccountID[:])
+	return addr
+}
+
+// Get the X-address string.
+func (xAddr *XAddress) String() string {
+	data := make([]byte, 0, xAddressLen)
+	if xAddr.Test {
+		data = append(data, xAddressTestPrefix...)
+	} else {
+		data = append(data, xAddressMainPrefix...)
+	}
+	data = append(data, xAddr.AccountID[:]...)
+
+	var tag [1 + 8]byte
+	if xAddr.HasTag {
+		tag[0] = 1
+		binary.LittleEndian.PutUint32(tag[1:], xAddr.Tag)
+	}
+	data = append(data, tag[:]...)
+
+	return base58Xrp.CheckEncode(data)
+}
+
+//
+// Not-exported functions
+//
+
+// Decode the specified string, checking version and payload length
+func decodeVersion(input string, version []byte, payloadLen int) ([]byte, error) {
+	dec, err := base58Xrp.CheckDecode(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(dec) != len(version) + payloadLen {
+		return nil, ErrInvalidLength
+	}
+	if !bytes.HasPrefix(dec, version) {
+		return nil, ErrInvalidVersion
+	}
+
+	return dec[len(version):], nil
+}
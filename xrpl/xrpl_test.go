@@ -0,0 +1,275 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xrpl
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ebellocchia/go-base58"
+)
+
+//
+// Types
+//
+
+// Single test vector entry structure for X-addresses
+type testVectXAddrEntry struct {
+	Classic string
+	Tag     uint32
+	HasTag  bool
+	Main    string
+	Test    string
+}
+
+//
+// Variables
+//
+
+// Account IDs (from the official XRPL address codec)
+var testVectAccountID = []struct {
+	Addr string
+	Hex  string
+} {
+	{ "rJrRMgiRgrU6hDF4pgu5DXQdWyPbY35ErN", "ba8e78626ee42c41b46d46c3048df3a1c3c87072" },
+	{ "rrrrrrrrrrrrrrrrrrrrrhoLvTp", "0000000000000000000000000000000000000000" },
+	{ "rrrrrrrrrrrrrrrrrrrrBZbvji", "0000000000000000000000000000000000000001" },
+}
+
+// Node public keys (from the official XRPL address codec)
+var testVectNodePublicKey = []struct {
+	Key string
+	Hex string
+} {
+	{ "n9MXXueo837zYH36DvMc13BwHcqtfAWNJY5czWVbp7uYTj7x17TH", "0388e5ba87a000cb807240df8c848eb0b5ffa5c8e5a521bc8e105c0f0a44217828" },
+}
+
+// Seeds (from the official XRPL address codec)
+var testVectSeed = []struct {
+	Seed    string
+	Hex     string
+	KeyType KeyType
+} {
+	{ "sn259rEFXrQrWyx3Q7XneWcwV6dfL", "cf2de378fbdd7e2ee87d486dfb5a7bff", Secp256k1 },
+	{ "sEdTM1uX8pu2do5XvTnutH6HsouMaM2", "4c3a1d213fbdfb14c7c28d609469b341", Ed25519 },
+}
+
+// X-addresses (from the official XRPL address codec)
+var testVectXAddr = []testVectXAddrEntry {
+	testVectXAddrEntry {
+		Classic: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		HasTag:  false,
+		Main:    "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ",
+		Test:    "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ",
+	},
+	testVectXAddrEntry {
+		Classic: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		Tag:     1,
+		HasTag:  true,
+		Main:    "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu",
+		Test:    "T719a5UwUCnEs54UsxG9CJYYDhwmFCvbJNZbi37gBGkRkbE",
+	},
+	testVectXAddrEntry {
+		Classic: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		Tag:     14,
+		HasTag:  true,
+		Main:    "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGo2K5VpXpmCqbV2gS",
+		Test:    "T719a5UwUCnEs54UsxG9CJYYDhwmFCvqXVCALUGJGSbNV3x",
+	},
+	testVectXAddrEntry {
+		Classic: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		Tag:     11747,
+		HasTag:  true,
+		Main:    "X7AcgcsBL6XDcUb289X4mJ8djcdyKaLFuhLRuNXPrDeJd9A",
+		Test:    "T719a5UwUCnEs54UsxG9CJYYDhwmFCziiNHtUukubF2Mg6t",
+	},
+}
+
+//
+// Functions
+//
+
+// Test account IDs
+func TestAccountID(t *testing.T) {
+	for _, currTest := range testVectAccountID {
+		accountID, _ := hex.DecodeString(currTest.Hex)
+
+		dec, err := DecodeAccountID(currTest.Addr)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Addr, err.Error())
+		} else if !bytes.Equal(dec, accountID) {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Addr, currTest.Hex, dec)
+		}
+
+		enc, err := EncodeAccountID(accountID)
+		if err != nil || enc != currTest.Addr {
+			t.Errorf("Encoding (%s) was incorrect: expected %s, got: %s", currTest.Hex, currTest.Addr, enc)
+		}
+	}
+}
+
+// Test node public keys
+func TestNodePublicKey(t *testing.T) {
+	for _, currTest := range testVectNodePublicKey {
+		key, _ := hex.DecodeString(currTest.Hex)
+
+		dec, err := DecodeNodePublicKey(currTest.Key)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Key, err.Error())
+		} else if !bytes.Equal(dec, key) {
+			t.Errorf("Decoding (%s) was incorrect: expected %s, got: %x", currTest.Key, currTest.Hex, dec)
+		}
+
+		enc, err := EncodeNodePublicKey(key)
+		if err != nil || enc != currTest.Key {
+			t.Errorf("Encoding (%s) was incorrect: expected %s, got: %s", currTest.Hex, currTest.Key, enc)
+		}
+	}
+}
+
+// Test seeds
+func TestSeed(t *testing.T) {
+	for _, currTest := range testVectSeed {
+		seed, _ := hex.DecodeString(currTest.Hex)
+
+		dec, keyType, err := DecodeSeed(currTest.Seed)
+		if err != nil {
+			t.Errorf("Decoding (%s) returned error: %s", currTest.Seed, err.Error())
+		} else if !bytes.Equal(dec, seed) || keyType != currTest.KeyType {
+			t.Errorf("Decoding (%s) was incorrect: expected %s (%d), got: %x (%d)", currTest.Seed, currTest.Hex, currTest.KeyType, dec, keyType)
+		}
+
+		enc, err := EncodeSeed(seed, currTest.KeyType)
+		if err != nil || enc != currTest.Seed {
+			t.Errorf("Encoding (%s) was incorrect: expected %s, got: %s", currTest.Hex, currTest.Seed, enc)
+		}
+	}
+}
+
+// Test X-addresses
+func TestXAddress(t *testing.T) {
+	for _, currTest := range testVectXAddr {
+		for _, test := range []bool { false, true } {
+			expAddr := currTest.Main
+			if test {
+				expAddr = currTest.Test
+			}
+
+			// Encode
+			xAddr, err := NewXAddress(currTest.Classic, currTest.Tag, currTest.HasTag, test)
+			if err != nil {
+				t.Errorf("Creating X-address (%s) returned error: %s", expAddr, err.Error())
+				continue
+			}
+			if xAddr.String() != expAddr {
+				t.Errorf("Encoding was incorrect: expected %s, got: %s", expAddr, xAddr.String())
+			}
+
+			// Decode
+			xAddr, err = DecodeXAddress(expAddr)
+			if err != nil {
+				t.Errorf("Decoding (%s) returned error: %s", expAddr, err.Error())
+				continue
+			}
+			if xAddr.ClassicAddress() != currTest.Classic || xAddr.Tag != currTest.Tag || xAddr.HasTag != currTest.HasTag || xAddr.Test != test {
+				t.Errorf("Decoding (%s) was incorrect: got %v", expAddr, xAddr)
+			}
+		}
+	}
+}
+
+// Test invalid inputs
+func TestInvalid(t *testing.T) {
+	accountID, _ := hex.DecodeString(testVectAccountID[0].Hex)
+	seed, _ := hex.DecodeString(testVectSeed[0].Hex)
+
+	// Invalid lengths
+	if _, err := EncodeAccountID(accountID[1:]); err != ErrInvalidLength {
+		t.Errorf("Encoding short account ID returned wrong error")
+	}
+	if _, err := EncodeNodePublicKey(accountID); err != ErrInvalidLength {
+		t.Errorf("Encoding short node public key returned wrong error")
+	}
+	if _, err := EncodeSeed(accountID, Secp256k1); err != ErrInvalidLength {
+		t.Errorf("Encoding long seed returned wrong error")
+	}
+	if _, err := DecodeAccountID(base58Xrp.CheckEncodeVersion([]byte { 0x00 }, seed)); err != ErrInvalidLength {
+		t.Errorf("Decoding short account ID returned wrong error")
+	}
+	if _, _, err := DecodeSeed(base58Xrp.CheckEncodeVersion([]byte { 0x21 }, accountID)); err != ErrInvalidLength {
+		t.Errorf("Decoding long seed returned wrong error")
+	}
+	if _, err := DecodeXAddress(testVectAccountID[0].Addr); err != ErrInvalidLength {
+		t.Errorf("Decoding classic address as X-address returned wrong error")
+	}
+
+	// Invalid versions
+	if _, err := DecodeAccountID(testVectNodePublicKey[0].Key); err != ErrInvalidLength {
+		t.Errorf("Decoding node public key as account ID returned wrong error")
+	}
+	if _, err := DecodeAccountID(base58Xrp.CheckEncodeVersion([]byte { 0x1c }, accountID)); err != ErrInvalidVersion {
+		t.Errorf("Decoding account ID with invalid version returned wrong error")
+	}
+	if _, _, err := DecodeSeed(base58Xrp.CheckEncodeVersion([]byte { 0x22 }, seed)); err != ErrInvalidVersion {
+		t.Errorf("Decoding secp256k1 seed with invalid version returned wrong error")
+	}
+	if _, _, err := DecodeSeed(base58Xrp.CheckEncodeVersion([]byte { 0x01, 0xe1, 0x4c }, seed)); err != ErrInvalidVersion {
+		t.Errorf("Decoding ed25519 seed with invalid version returned wrong error")
+	}
+	if _, err := EncodeSeed(seed, KeyType(2)); err != ErrInvalidKeyType {
+		t.Errorf("Encoding seed with invalid key type returned wrong error")
+	}
+
+	// Invalid X-addresses
+	xAddrPayload := func(prefix []byte, flag byte, tag []byte) string {
+		data := append(append([]byte {}, prefix...), accountID...)
+		data = append(data, flag)
+		data = append(data, tag...)
+		data = append(data, make([]byte, 8 - len(tag))...)
+		return base58Xrp.CheckEncode(data)
+	}
+	if _, err := DecodeXAddress(xAddrPayload([]byte { 0x05, 0x45 }, 0, nil)); err != ErrInvalidVersion {
+		t.Errorf("Decoding X-address with invalid prefix returned wrong error")
+	}
+	if _, err := DecodeXAddress(xAddrPayload([]byte { 0x05, 0x44 }, 2, nil)); err != ErrInvalidTag {
+		t.Errorf("Decoding X-address with invalid tag flag returned wrong error")
+	}
+	if _, err := DecodeXAddress(xAddrPayload([]byte { 0x05, 0x44 }, 0, []byte { 0x01 })); err != ErrInvalidTag {
+		t.Errorf("Decoding X-address with tag but no flag returned wrong error")
+	}
+	if _, err := DecodeXAddress(xAddrPayload([]byte { 0x04, 0x93 }, 1, []byte { 0, 0, 0, 0, 1 })); err != ErrInvalidTag {
+		t.Errorf("Decoding X-address with 64-bit tag returned wrong error")
+	}
+
+	// Invalid checksum and characters
+	invalidAddr := testVectXAddr[0].Main[:len(testVectXAddr[0].Main) - 1] + "1"
+	if _, err := DecodeXAddress(invalidAddr); err != base58.ErrInvalidChecksum {
+		t.Errorf("Decoding X-address with invalid checksum returned wrong error")
+	}
+	if _, err := NewXAddress(strings.Replace(testVectAccountID[0].Addr, "J", "0", 1), 0, false, false); err == nil {
+		t.Errorf("Creating X-address from invalid classic address returned no error")
+	}
+}